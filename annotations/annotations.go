@@ -1,7 +1,13 @@
 package annotations
 
 import (
+	"context"
+	"fmt"
+	"math"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Component 组件标记接口
@@ -34,6 +40,11 @@ type Destroyer interface {
 	Destroy() error
 }
 
+// ContextDestroyer 接收上下文的销毁接口，上下文在销毁超时或停止的总时限耗尽时结束
+type ContextDestroyer interface {
+	Destroy(ctx context.Context) error
+}
+
 // PostConstruct 构造后回调接口
 type PostConstruct interface {
 	PostConstruct() error
@@ -44,6 +55,11 @@ type PreDestroy interface {
 	PreDestroy() error
 }
 
+// ContextPreDestroy 接收上下文的销毁前回调接口
+type ContextPreDestroy interface {
+	PreDestroy(ctx context.Context) error
+}
+
 // BeanNameAware Bean名称感知接口
 type BeanNameAware interface {
 	SetBeanName(name string)
@@ -54,6 +70,18 @@ type ContainerAware interface {
 	SetContainer(container interface{})
 }
 
+// Ordered 排序接口，注入切片或映射时按 Order 从小到大排列
+type Ordered interface {
+	Order() int
+}
+
+const (
+	// HighestPrecedence 最高优先级的排序值
+	HighestPrecedence = math.MinInt32
+	// LowestPrecedence 最低优先级的排序值，未声明排序的Bean使用该值
+	LowestPrecedence = math.MaxInt32
+)
+
 // AnnotationUtils 注解工具类
 type AnnotationUtils struct{}
 
@@ -161,44 +189,245 @@ func (au *AnnotationUtils) GetInjectFields(typ reflect.Type) []reflect.StructFie
 
 // IsSingleton 检查是否为单例
 func (au *AnnotationUtils) IsSingleton(typ reflect.Type) bool {
+	return au.GetScope(typ) == "singleton"
+}
+
+// GetScope 获取Bean的作用域
+//
+// scope 标签可以是 singleton、prototype 或任意自定义作用域名称；
+// singleton:"false" 等价于 scope:"prototype"，refreshScope:"true" 等价于 scope:"refresh"。未声明时默认为单例。
+func (au *AnnotationUtils) GetScope(typ reflect.Type) string {
 	if typ == nil {
-		return true // 默认为单例
+		return "singleton"
 	}
-	
+
 	if typ.Kind() == reflect.Ptr {
 		typ = typ.Elem()
 	}
-	
+
 	if typ.Kind() != reflect.Struct {
-		return true // 默认为单例
+		return "singleton"
 	}
 
 	for i := 0; i < typ.NumField(); i++ {
 		field := typ.Field(i)
 		if value := field.Tag.Get("singleton"); value != "" {
-			return value == "true"
+			if value == "true" {
+				return "singleton"
+			}
+			return "prototype"
 		}
 		if value := field.Tag.Get("scope"); value != "" {
-			return value == "singleton"
+			return value
+		}
+		if field.Tag.Get("refreshScope") == "true" {
+			return "refresh"
 		}
 	}
 
-	// 默认为单例
-	return true
+	return "singleton"
 }
+// IsPrimary 检查类型是否标记为同类型Bean中的首选实现
+func (au *AnnotationUtils) IsPrimary(typ reflect.Type) bool {
+	if typ == nil {
+		return false
+	}
 
-// GetScope 获取Bean的作用域
-func (au *AnnotationUtils) GetScope(typ reflect.Type) string {
 	if typ.Kind() == reflect.Ptr {
 		typ = typ.Elem()
 	}
 
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		if value := typ.Field(i).Tag.Get("primary"); value != "" {
+			return value == "true"
+		}
+	}
+	return false
+}
+
+// GetQualifiers 获取类型声明的限定符，格式为 qualifier:"mysql,primaryDb"
+//
+// 带有inject标签的字段上的qualifier用于选择依赖，不计入Bean自身的限定符。
+func (au *AnnotationUtils) GetQualifiers(typ reflect.Type) []string {
+	if typ == nil {
+		return nil
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var qualifiers []string
 	for i := 0; i < typ.NumField(); i++ {
 		field := typ.Field(i)
-		if value := field.Tag.Get("scope"); value != "" {
-			return value
+		if _, isInjectionPoint := field.Tag.Lookup("inject"); isInjectionPoint {
+			continue
+		}
+		for _, qualifier := range strings.Split(field.Tag.Get("qualifier"), ",") {
+			if qualifier = strings.TrimSpace(qualifier); qualifier != "" {
+				qualifiers = append(qualifiers, qualifier)
+			}
 		}
 	}
+	return qualifiers
+}
 
-	return "singleton"
-}
\ No newline at end of file
+// GetDependsOn 获取类型声明的必须先初始化的Bean，格式为 dependsOn:"migrations,schema"
+func (au *AnnotationUtils) GetDependsOn(typ reflect.Type) []string {
+	if typ == nil {
+		return nil
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var names []string
+	for i := 0; i < typ.NumField(); i++ {
+		for _, name := range strings.Split(typ.Field(i).Tag.Get("dependsOn"), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// GetProfiles 获取类型声明的配置文件表达式，格式为 profile:"dev,!prod"
+func (au *AnnotationUtils) GetProfiles(typ reflect.Type) []string {
+	if typ == nil {
+		return nil
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var profiles []string
+	for i := 0; i < typ.NumField(); i++ {
+		for _, profile := range strings.Split(typ.Field(i).Tag.Get("profile"), ",") {
+			if profile = strings.TrimSpace(profile); profile != "" {
+				profiles = append(profiles, profile)
+			}
+		}
+	}
+	return profiles
+}
+
+// GetConfigPrefix 获取类型上 config 标签声明的配置属性前缀，未声明时返回空字符串
+//
+// 只读取 _ 标记字段上的 config 标签，其他字段上的 config 标签用于指定字段对应的属性名。
+func (au *AnnotationUtils) GetConfigPrefix(typ reflect.Type) string {
+	if typ == nil {
+		return ""
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return ""
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		if field := typ.Field(i); field.Name == "_" {
+			if prefix := strings.TrimSpace(field.Tag.Get("config")); prefix != "" {
+				return prefix
+			}
+		}
+	}
+	return ""
+}
+
+// GetOrder 获取类型上 order 标签声明的排序值，未声明时返回 false，标签值不是整数时返回错误
+func (au *AnnotationUtils) GetOrder(typ reflect.Type) (int, bool, error) {
+	if typ == nil {
+		return 0, false, nil
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return 0, false, nil
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		if value := typ.Field(i).Tag.Get("order"); value != "" {
+			order, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return 0, false, fmt.Errorf("invalid order tag %q on %v: must be an integer", value, typ)
+			}
+			return order, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// IsLazy 检查类型是否声明为延迟创建，格式为 lazy:"true"
+func (au *AnnotationUtils) IsLazy(typ reflect.Type) bool {
+	if typ == nil {
+		return false
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		if value := typ.Field(i).Tag.Get("lazy"); value != "" {
+			return value == "true"
+		}
+	}
+	return false
+}
+
+// GetDestroyTimeout 获取类型上 destroyTimeout 标签声明的销毁时限，例如 destroyTimeout:"5s"
+//
+// 未声明时返回 false，标签值不是正的时长时返回错误。
+func (au *AnnotationUtils) GetDestroyTimeout(typ reflect.Type) (time.Duration, bool, error) {
+	if typ == nil {
+		return 0, false, nil
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return 0, false, nil
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		if value := typ.Field(i).Tag.Get("destroyTimeout"); value != "" {
+			timeout, err := time.ParseDuration(strings.TrimSpace(value))
+			if err != nil || timeout <= 0 {
+				return 0, false, fmt.Errorf("invalid destroyTimeout tag %q on %v: must be a positive duration", value, typ)
+			}
+			return timeout, true, nil
+		}
+	}
+	return 0, false, nil
+}
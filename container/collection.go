@@ -0,0 +1,101 @@
+package container
+
+import (
+	"reflect"
+	"sort"
+
+	"gospring/annotations"
+)
+
+// collectionElem 判断字段是否为集合注入点，返回元素类型
+//
+// 支持 []T 和 map[string]T，T 通常为接口类型。
+func collectionElem(typ reflect.Type) (reflect.Type, bool) {
+	switch typ.Kind() {
+	case reflect.Slice:
+		return typ.Elem(), true
+	case reflect.Map:
+		if typ.Key().Kind() == reflect.String {
+			return typ.Elem(), true
+		}
+	}
+	return nil, false
+}
+
+// collectionCandidates 按注册顺序查找集合注入的候选Bean，调用方需持有锁
+//
+// exclude 为声明该注入点的Bean名称，Bean不会被注入到自身的集合中。
+func (c *Container) collectionCandidates(elemType reflect.Type, qualifier, exclude string) []string {
+	var names []string
+	for _, name := range c.order {
+		beanDef := c.beans[name]
+		if name == exclude || !beanDef.instanceType.AssignableTo(elemType) {
+			continue
+		}
+		if qualifier != "" && !beanDef.HasQualifier(qualifier) {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// resolveCollection 创建集合注入点的值，包括父容器中的Bean，元素按排序值排列，排序值相同时保持注册顺序
+func (c *Container) resolveCollection(fieldType reflect.Type, qualifier, exclude string, cr creation) (reflect.Value, error) {
+	elemType, _ := collectionElem(fieldType)
+
+	c.mutex.RLock()
+	names := c.collectionCandidates(elemType, qualifier, exclude)
+	beanDefs := make([]*BeanDefinition, len(names))
+	for i, name := range names {
+		beanDefs[i] = c.beans[name]
+	}
+	c.mutex.RUnlock()
+
+	// 父容器中未被覆盖的Bean排在本地Bean之后
+	for _, beanDef := range c.ancestorCollectionCandidates(elemType, qualifier) {
+		names = append(names, beanDef.Name)
+		beanDefs = append(beanDefs, beanDef)
+	}
+
+	if len(names) == 0 {
+		return reflect.Value{}, &BeanNotFoundError{Type: elemType, Qualifier: qualifier}
+	}
+
+	type entry struct {
+		name  string
+		bean  interface{}
+		order int
+	}
+
+	entries := make([]entry, len(names))
+	for i, name := range names {
+		bean, err := c.getBean(name, cr)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		order := beanDefs[i].Order
+		if ordered, ok := bean.(annotations.Ordered); ok {
+			order = ordered.Order()
+		}
+		entries[i] = entry{name: name, bean: bean, order: order}
+	}
+
+	if fieldType.Kind() == reflect.Map {
+		result := reflect.MakeMapWithSize(fieldType, len(entries))
+		for _, e := range entries {
+			result.SetMapIndex(reflect.ValueOf(e.name).Convert(fieldType.Key()), reflect.ValueOf(e.bean))
+		}
+		return result, nil
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].order < entries[j].order
+	})
+
+	result := reflect.MakeSlice(fieldType, 0, len(entries))
+	for _, e := range entries {
+		result = reflect.Append(result, reflect.ValueOf(e.bean))
+	}
+	return result, nil
+}
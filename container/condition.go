@@ -0,0 +1,214 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gospring/environment"
+	"gospring/logging"
+)
+
+// Condition Bean的注册条件，条件不满足的Bean在装配前从容器中移除
+type Condition interface {
+	// Matches 判断条件是否满足
+	Matches(ctx ConditionContext) bool
+	// String 返回条件的描述，用于记录被跳过的原因
+	String() string
+}
+
+// ConditionContext 判断条件时可用的运行环境和已注册的Bean
+type ConditionContext struct {
+	Environment environment.Environment
+	BeanName    string // 正在判断的Bean名称
+
+	container *Container
+	pending   map[string]bool // 尚未判断的条件Bean
+}
+
+// ContainsBean 检查是否存在指定名称的Bean，不包括正在判断的Bean和尚未判断的条件Bean
+func (ctx ConditionContext) ContainsBean(name string) bool {
+	if ctx.container.HasLocalBean(name) {
+		def := ctx.container.GetBeanDefinition(name)
+		return def.Name != ctx.BeanName && !ctx.pending[def.Name]
+	}
+	if parent := ctx.container.GetParent(); parent != nil {
+		return parent.HasBean(name)
+	}
+	return false
+}
+
+// ContainsBeanOfType 检查是否存在可赋值给指定类型的Bean，不包括正在判断的Bean和尚未判断的条件Bean
+func (ctx ConditionContext) ContainsBeanOfType(typ reflect.Type) bool {
+	for _, name := range ctx.container.GetBeanNamesForType(typ) {
+		if name != ctx.BeanName && !ctx.pending[name] {
+			return true
+		}
+	}
+	if parent := ctx.container.GetParent(); parent != nil {
+		return len(parent.GetBeanNamesForTypeIncludingAncestors(typ)) > 0
+	}
+	return false
+}
+
+// Conditional 为Bean添加注册条件，多个条件需全部满足
+//
+// 条件在 ApplicationContext.Start 装配之前判断，直接使用容器时调用 EvaluateConditions。
+func Conditional(conditions ...Condition) BeanOption {
+	return func(beanDef *BeanDefinition) {
+		beanDef.Conditions = append(beanDef.Conditions, conditions...)
+	}
+}
+
+// ConditionalOn 以运行环境上的任意判断函数作为注册条件，description 用于说明跳过的原因
+func ConditionalOn(description string, predicate func(env environment.Environment) bool) BeanOption {
+	return Conditional(&conditionFunc{
+		description: description,
+		matches: func(ctx ConditionContext) bool {
+			return predicate(ctx.Environment)
+		},
+	})
+}
+
+// ConditionalOnProperty 属性满足要求时注册
+//
+// havingValue 为空时要求属性存在且不为 "false"，否则要求属性值与其相等（不区分大小写）。
+func ConditionalOnProperty(key, havingValue string) BeanOption {
+	description := fmt.Sprintf("property '%s' is set", key)
+	if havingValue != "" {
+		description = fmt.Sprintf("property '%s' is '%s'", key, havingValue)
+	}
+	return Conditional(&conditionFunc{
+		description: description,
+		matches: func(ctx ConditionContext) bool {
+			value, exists := ctx.Environment.GetProperty(key)
+			if !exists {
+				return false
+			}
+			if havingValue == "" {
+				return !strings.EqualFold(value, "false")
+			}
+			return strings.EqualFold(value, havingValue)
+		},
+	})
+}
+
+// ConditionalOnProfile 配置文件表达式中有任意一个成立时注册，例如 "dev" 或 "!prod"
+//
+// 与 Profile 选项不同，条件在 Start 时才判断。
+func ConditionalOnProfile(profiles ...string) BeanOption {
+	return Conditional(&conditionFunc{
+		description: profileDescription(profiles),
+		matches: func(ctx ConditionContext) bool {
+			return ctx.Environment.AcceptsProfiles(profiles...)
+		},
+	})
+}
+
+// ConditionalOnBean 存在可赋值给指定类型的其他Bean时注册
+func ConditionalOnBean(typ reflect.Type) BeanOption {
+	return Conditional(&conditionFunc{
+		description: fmt.Sprintf("bean of type %v is present", typ),
+		matches: func(ctx ConditionContext) bool {
+			return ctx.ContainsBeanOfType(typ)
+		},
+	})
+}
+
+// ConditionalOnMissingBean 不存在可赋值给指定类型的其他Bean时注册
+//
+// 用于库提供默认实现：应用自行注册同类型的Bean时，默认实现被跳过。
+func ConditionalOnMissingBean(typ reflect.Type) BeanOption {
+	return Conditional(&conditionFunc{
+		description: fmt.Sprintf("no bean of type %v is present", typ),
+		matches: func(ctx ConditionContext) bool {
+			return !ctx.ContainsBeanOfType(typ)
+		},
+	})
+}
+
+// conditionFunc 以函数实现的条件
+type conditionFunc struct {
+	description string
+	matches     func(ctx ConditionContext) bool
+}
+
+func (c *conditionFunc) Matches(ctx ConditionContext) bool {
+	return c.matches(ctx)
+}
+
+func (c *conditionFunc) String() string {
+	return c.description
+}
+
+// EvaluateConditions 判断所有条件Bean，移除条件不满足的Bean，返回被移除的Bean名称
+//
+// env 为nil时使用容器的运行环境。没有条件的Bean总是保留。条件Bean按注册顺序依次判断，ConditionalOnBean 和
+// ConditionalOnMissingBean 只能看到没有条件的Bean和之前已通过判断的条件Bean。
+func (c *Container) EvaluateConditions(env environment.Environment) []string {
+	if env == nil {
+		env = c.GetEnvironment()
+	}
+
+	pending := make(map[string]bool)
+	var conditional []*BeanDefinition
+	c.mutex.RLock()
+	for _, name := range c.order {
+		if beanDef := c.beans[name]; len(beanDef.Conditions) > 0 {
+			pending[name] = true
+			conditional = append(conditional, beanDef)
+		}
+	}
+	c.mutex.RUnlock()
+
+	var skipped []string
+	for _, beanDef := range conditional {
+		ctx := ConditionContext{Environment: env, BeanName: beanDef.Name, container: c, pending: pending}
+		failed := failedCondition(beanDef.Conditions, ctx)
+		delete(pending, beanDef.Name)
+		if failed == nil {
+			continue
+		}
+
+		c.removeDefinition(beanDef.Name)
+		skipped = append(skipped, beanDef.Name)
+		c.logger.LogEvent(&logging.BeanSkipped{
+			Timestamp:     time.Now(),
+			ComponentID:   beanDef.Name,
+			ComponentType: beanDef.Type.String(),
+			Condition:     failed.String(),
+		})
+	}
+	return skipped
+}
+
+// failedCondition 返回第一个不满足的条件，全部满足时返回nil
+func failedCondition(conditions []Condition, ctx ConditionContext) Condition {
+	for _, condition := range conditions {
+		if !condition.Matches(ctx) {
+			return condition
+		}
+	}
+	return nil
+}
+
+// removeDefinition 从容器中移除Bean定义及其类型映射
+func (c *Container) removeDefinition(name string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.beans, name)
+	for i, n := range c.order {
+		if n == name {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+	for typ, mapped := range c.typeMapping {
+		if mapped == name {
+			delete(c.typeMapping, typ)
+			delete(c.autoBound, typ)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"gospring/environment"
+)
+
+// RebindConfigProperties 将运行环境中的属性重新绑定到所有配置对象
+//
+// 每个配置对象从注册时的初始值开始绑定到新的实例，所有配置对象都绑定成功后才一次性替换容器中的实例，
+// 任一属性无效时所有配置对象保持原值，并返回列出所有无效属性的 *environment.BindingError。
+// 原有的实例不会被修改，已经注入该实例的Bean继续看到替换前的值，可以并发读取；
+// 需要读取最新配置时通过 Provider[T] 或 GetBean 获取。
+func (c *Container) RebindConfigProperties() error {
+	c.mutex.RLock()
+	beanDefs := make([]*BeanDefinition, 0, len(c.order))
+	for _, name := range c.order {
+		beanDefs = append(beanDefs, c.beans[name])
+	}
+	c.mutex.RUnlock()
+
+	return c.bindConfigProperties(beanDefs, true)
+}
+
+// bindConfigProperties 将运行环境中的属性绑定到声明了配置前缀的Bean
+//
+// 所有Bean的无效属性汇总为一个 *environment.BindingError 返回，此时不修改任何实例。
+// replace 为 false 时在装配前原地更新注册的实例；为 true 时以新的实例替换，供运行期间重新绑定使用，
+// 替换在Bean定义的锁中进行，不会与读取实例的协程产生数据竞争。
+func (c *Container) bindConfigProperties(beanDefs []*BeanDefinition, replace bool) error {
+	env := c.GetEnvironment()
+
+	var invalid []*environment.InvalidPropertyError
+	bound := make(map[*BeanDefinition]reflect.Value)
+	for _, beanDef := range beanDefs {
+		if beanDef.ConfigPrefix == "" || beanDef.factory.IsValid() {
+			continue
+		}
+
+		target, err := beanDef.configTarget()
+		if err == nil {
+			err = environment.Bind(env, beanDef.ConfigPrefix, target.Interface())
+		}
+		var bindingErr *environment.BindingError
+		if errors.As(err, &bindingErr) {
+			invalid = append(invalid, bindingErr.Errors...)
+			continue
+		} else if err != nil {
+			return &BeanCreationError{Name: beanDef.Name, Type: beanDef.Type, Cause: err}
+		}
+		bound[beanDef] = target.Elem()
+	}
+
+	if len(invalid) > 0 {
+		return &environment.BindingError{Errors: invalid}
+	}
+
+	for beanDef, value := range bound {
+		instance := reflect.ValueOf(beanDef.getInstance()).Elem()
+		// 保留容器注入的字段
+		for i := 0; i < value.NumField(); i++ {
+			if _, injected := value.Type().Field(i).Tag.Lookup("inject"); injected && value.Field(i).CanSet() {
+				value.Field(i).Set(instance.Field(i))
+			}
+		}
+		if replace {
+			beanDef.setInstance(value.Addr().Interface())
+		} else {
+			instance.Set(value)
+		}
+	}
+	return nil
+}
+
+// configTarget 以配置对象注册时的初始值创建新的绑定目标，首次调用时保存初始值
+func (beanDef *BeanDefinition) configTarget() (reflect.Value, error) {
+	instance := reflect.ValueOf(beanDef.getInstance())
+	if instance.Kind() != reflect.Ptr || instance.IsNil() || instance.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("configuration properties bean must be a non-nil pointer to struct, got %v", beanDef.Type)
+	}
+
+	beanDef.mutex.Lock()
+	if !beanDef.configTemplate.IsValid() {
+		beanDef.configTemplate = reflect.New(instance.Elem().Type()).Elem()
+		beanDef.configTemplate.Set(instance.Elem())
+	}
+	template := beanDef.configTemplate
+	beanDef.mutex.Unlock()
+
+	target := reflect.New(template.Type())
+	target.Elem().Set(template)
+	return target, nil
+}
@@ -3,27 +3,64 @@ package container
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
+	"gospring/environment"
 	"gospring/logging"
 )
 
 // BeanDefinition 定义Bean的元数据
+
 type BeanDefinition struct {
-	Name      string
-	Type      reflect.Type
-	Value     reflect.Value
-	Singleton bool
-	Instance  interface{}
-	mutex     sync.RWMutex
+	Name           string
+	Type           reflect.Type
+	Value          reflect.Value
+	Singleton      bool   // 是否为单例，等价于 Scope == ScopeSingleton
+	Scope          string // 作用域名称：singleton、prototype 或已注册的自定义作用域
+	Instance       interface{}
+	Factory        interface{}     // 构造函数，通过RegisterProvider注册时非空
+	Primary        bool            // 同类型存在多个Bean时是否为首选
+	Qualifiers     []string        // 限定符，注入点可通过 qualifier 标签按限定符选择Bean
+	Order          int             // 注入切片或映射时的排序值，越小越靠前
+	Lazy           bool            // 延迟到首次获取时才创建、注入并初始化
+	Overrides      *BeanDefinition // 被本定义覆盖的同名定义，没有覆盖时为nil
+	DependsOn      []string        // 必须先于该Bean初始化的Bean名称
+	Conditions     []Condition     // 注册条件，不满足时Bean在装配前被移除
+	Profiles       []string        // 配置文件表达式，不成立时不注册
+	ConfigPrefix   string          // 配置属性前缀，非空时装配前将属性绑定到实例
+	DestroyTimeout time.Duration   // 停止上下文时销毁该Bean的时限，为0时使用上下文的默认时限
+	mutex          sync.RWMutex
+
+	lazyInitialized bool // 延迟Bean是否已完成初始化
+
+	instanceType   reflect.Type  // 实例的原始类型（可能为指针）
+	factory        reflect.Value // 构造函数的反射值
+	configTemplate reflect.Value // 配置对象注册时的初始值，重新绑定时从该值开始
 }
 
 // Container IoC容器
 type Container struct {
 	beans       map[string]*BeanDefinition
+	order       []string                // Bean的注册顺序
 	typeMapping map[reflect.Type]string // 类型到Bean名称的映射
+	autoBound   map[reflect.Type]bool   // 自动建立的类型映射，注册新Bean时可能失效
+	scopes      map[string]Scope        // 已注册的自定义作用域
 	mutex       sync.RWMutex
 	logger      logging.Logger // 日志器
+
+	allowCircularReferences bool // 是否允许单例之间的字段注入循环
+	strictWiring            bool // 严格装配模式，无法解析的依赖会导致装配失败
+	lifecycleProcessor      LifecycleProcessor
+	parent                  *Container // 父容器，本地找不到的Bean在父容器中查找
+	aliases                 map[string]string // 别名到Bean名称的映射
+	overridePolicy          OverridePolicy    // 注册同名Bean时的处理策略
+	environment             environment.Environment
+	resolvable              map[reflect.Type]interface{} // 可按类型注入但不作为Bean管理的对象
+	refreshScope            *SimpleScope                 // 内置的刷新作用域
+
+	creating      map[*BeanDefinition]*singletonCreation // 正在创建的单例
+	creatingMutex sync.Mutex
 }
 
 // NewContainer 创建新的容器实例
@@ -36,8 +73,17 @@ func NewContainerWithLogger(logger logging.Logger) *Container {
 	container := &Container{
 		beans:       make(map[string]*BeanDefinition),
 		typeMapping: make(map[reflect.Type]string),
+		autoBound:   make(map[reflect.Type]bool),
+		scopes:      make(map[string]Scope),
+		aliases:     make(map[string]string),
+		resolvable:  make(map[reflect.Type]interface{}),
+		creating:    make(map[*BeanDefinition]*singletonCreation),
 		logger:      logger,
+
+		strictWiring: true,
+		refreshScope: NewSimpleScope(),
 	}
+	container.SetEnvironment(environment.NewStandardEnvironment())
 	
 	// 记录容器创建事件
 	container.logger.LogEvent(&logging.ContainerCreated{
@@ -48,24 +94,20 @@ func NewContainerWithLogger(logger logging.Logger) *Container {
 }
 
 // RegisterSingleton 注册单例Bean
-func (c *Container) RegisterSingleton(name string, instance interface{}) error {
-	return c.registerBean(name, instance, true)
+func (c *Container) RegisterSingleton(name string, instance interface{}, opts ...BeanOption) error {
+	return c.registerBean(name, instance, ScopeSingleton, opts)
 }
 
 // RegisterPrototype 注册原型Bean
-func (c *Container) RegisterPrototype(name string, instance interface{}) error {
-	return c.registerBean(name, instance, false)
+func (c *Container) RegisterPrototype(name string, instance interface{}, opts ...BeanOption) error {
+	return c.registerBean(name, instance, ScopePrototype, opts)
 }
 
 // registerBean 内部注册Bean方法
-func (c *Container) registerBean(name string, instance interface{}, singleton bool) error {
+func (c *Container) registerBean(name string, instance interface{}, scope string, opts []BeanOption) error {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	if _, exists := c.beans[name]; exists {
-		return fmt.Errorf("bean with name '%s' already exists", name)
-	}
-
 	val := reflect.ValueOf(instance)
 	typ := reflect.TypeOf(instance)
 	originalType := typ
@@ -76,26 +118,26 @@ func (c *Container) registerBean(name string, instance interface{}, singleton bo
 	}
 
 	beanDef := &BeanDefinition{
-		Name:      name,
-		Type:      typ,
-		Value:     val,
-		Singleton: singleton,
-		Instance:  instance,
+		Name:         name,
+		Type:         typ,
+		Value:        val,
+		Singleton:    scope == ScopeSingleton,
+		Scope:        scope,
+		Instance:     instance,
+		instanceType: originalType,
+	}
+	if err := beanDef.applyOptions(opts); err != nil {
+		return fmt.Errorf("invalid bean '%s': %w", name, err)
 	}
 
-	c.beans[name] = beanDef
-	// 同时注册指针类型和元素类型的映射
-	c.typeMapping[typ] = name
-	c.typeMapping[originalType] = name
-
-	// 如果实现了接口，也注册接口映射
-	c.registerInterfaces(instance, name)
+	if !c.acceptsProfiles(beanDef) {
+		return nil
+	}
+	if err := c.addDefinition(beanDef); err != nil {
+		return err
+	}
 
 	// 记录组件注册事件
-	scope := "singleton"
-	if !singleton {
-		scope = "prototype"
-	}
 	c.logger.LogEvent(&logging.ComponentRegistered{
 		Timestamp:     time.Now(),
 		ComponentID:   name,
@@ -106,184 +148,458 @@ func (c *Container) registerBean(name string, instance interface{}, singleton bo
 	return nil
 }
 
-// registerInterfaces 注册接口映射
-func (c *Container) registerInterfaces(instance interface{}, beanName string) {
-	typ := reflect.TypeOf(instance)
-	
-	// 遍历所有接口
-	for i := 0; i < typ.NumMethod(); i++ {
-		method := typ.Method(i)
-		// 检查是否实现了某个接口
-		if method.Type.NumIn() > 0 {
-			// 这里可以扩展更复杂的接口检测逻辑
+// registerInterfaces 维护类型映射，调用方需持有写锁
+//
+// 结构体和接口类型的映射在首次按类型查找时自动建立并缓存，新注册的Bean若同样
+// 可赋值给已缓存的类型，则清除该缓存，下次查找时重新检查候选是否唯一，
+// 而不是由后注册的Bean静默覆盖之前的映射。
+func (c *Container) registerInterfaces(typ reflect.Type, beanName string) {
+	for cached := range c.autoBound {
+		if typ.AssignableTo(cached) && c.typeMapping[cached] != beanName {
+			delete(c.typeMapping, cached)
+			delete(c.autoBound, cached)
 		}
 	}
 }
 
 // GetBean 获取Bean实例
 func (c *Container) GetBean(name string) interface{} {
+	bean, _ := c.getBean(name, creation{})
+	return bean
+}
+
+// getBean 获取Bean实例，cr 记录当前的创建链，用于检测构造过程中的循环
+func (c *Container) getBean(name string, cr creation) (interface{}, error) {
 	c.mutex.RLock()
-	beanDef, exists := c.beans[name]
+	beanDef, exists := c.beans[c.canonicalName(name)]
+	parent := c.parent
 	c.mutex.RUnlock()
 
 	if !exists {
-		return nil
+		if parent != nil {
+			// 父容器中的Bean有独立的创建链，只沿用请求上下文
+			return parent.getBean(name, creation{ctx: cr.ctx})
+		}
+		return nil, &BeanNotFoundError{Name: name}
+	}
+
+	if cr.isCreating(beanDef.Name) {
+		return nil, newCreationCycleError(cr.chain, beanDef.Name)
 	}
 
 	if beanDef.Singleton {
-		return beanDef.Instance
+		if beanDef.Lazy {
+			return c.getLazySingleton(beanDef, cr)
+		}
+		if beanDef.factory.IsValid() {
+			return c.getOrCreateSingleton(beanDef, cr)
+		}
+		return beanDef.getInstance(), nil
+	}
+
+	if beanDef.Scope != ScopePrototype {
+		return c.getScopedBean(beanDef, cr)
 	}
 
 	// 原型模式，创建新实例
-	return c.createNewInstance(beanDef)
+	return c.createNewInstance(beanDef, cr)
+}
+
+// LookupBean 获取Bean实例，Bean不存在或创建失败时返回错误
+func (c *Container) LookupBean(name string) (interface{}, error) {
+	return c.getBean(name, creation{})
 }
 
 // GetBeanByType 根据类型获取Bean
 func (c *Container) GetBeanByType(typ reflect.Type) interface{} {
-	c.mutex.RLock()
-	beanName, exists := c.typeMapping[typ]
-	c.mutex.RUnlock()
+	bean, _ := c.getBeanByType(typ, "", creation{})
+	return bean
+}
 
-	if !exists {
-		return nil
+// LookupBeanByType 根据类型获取Bean，Bean不存在或创建失败时返回错误
+//
+// 存在多个候选时，若恰好有一个被标记为首选则返回该Bean，否则返回 *NoUniqueBeanError。
+func (c *Container) LookupBeanByType(typ reflect.Type) (interface{}, error) {
+	return c.getBeanByType(typ, "", creation{})
+}
+
+// LookupQualifiedBean 在可赋值给指定类型的Bean中按限定符获取Bean
+//
+// Bean的名称同样视为限定符。
+func (c *Container) LookupQualifiedBean(typ reflect.Type, qualifier string) (interface{}, error) {
+	return c.getBeanByType(typ, qualifier, creation{})
+}
+
+// getBeanByType 根据类型和可选的限定符获取Bean
+func (c *Container) getBeanByType(typ reflect.Type, qualifier string, cr creation) (interface{}, error) {
+	var beanName string
+	var err error
+
+	if qualifier != "" {
+		c.mutex.RLock()
+		beanName, err = c.findBeanNameForType(typ, qualifier)
+		c.mutex.RUnlock()
+	} else {
+		c.mutex.RLock()
+		name, exists := c.typeMapping[typ]
+		c.mutex.RUnlock()
+
+		beanName = name
+		if !exists {
+			beanName, err = c.bindType(typ)
+		}
+	}
+	if err != nil {
+		if value, exists := c.resolvableDependency(typ); exists && qualifier == "" && isBeanNotFound(err) {
+			return value, nil
+		}
+		// 本容器中没有候选时在父容器中查找
+		if parent := c.GetParent(); parent != nil && isBeanNotFound(err) {
+			return parent.getBeanByType(typ, qualifier, creation{ctx: cr.ctx})
+		}
+		return nil, err
 	}
 
-	return c.GetBean(beanName)
+	return c.getBean(beanName, cr)
 }
 
-// createNewInstance 创建新的实例（用于原型模式）
-func (c *Container) createNewInstance(beanDef *BeanDefinition) interface{} {
-	start := time.Now()
-	
-	// 创建新实例
-	newVal := reflect.New(beanDef.Type)
-	newInstance := newVal.Interface()
+// bindType 在已注册的Bean中查找类型的唯一实现，找到后缓存到 typeMapping
+func (c *Container) bindType(typ reflect.Type) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 
-	// 执行依赖注入
-	c.InjectDependencies(newInstance)
+	if beanName, exists := c.typeMapping[typ]; exists {
+		return beanName, nil
+	}
 
-	// 记录组件创建事件
-	c.logger.LogEvent(&logging.ComponentCreated{
-		Timestamp:     time.Now(),
-		ComponentID:   beanDef.Name,
-		ComponentType: beanDef.Type.String(),
-		CreationTime:  time.Since(start),
-	})
+	beanName, err := c.findBeanNameForType(typ, "")
+	if err != nil {
+		return "", err
+	}
+
+	c.typeMapping[typ] = beanName
+	c.autoBound[typ] = true
+	return beanName, nil
+}
+
+// findBeanNameForType 按注册顺序查找可赋值给指定类型的唯一Bean，调用方需持有锁
+//
+// qualifier 非空时只考虑匹配该限定符的Bean；仍有多个候选时选择唯一的首选Bean。
+func (c *Container) findBeanNameForType(typ reflect.Type, qualifier string) (string, error) {
+	if qualifier == "" {
+		if beanName, exists := c.typeMapping[typ]; exists {
+			return beanName, nil
+		}
+	}
+
+	var candidates []string
+	for _, name := range c.order {
+		beanDef := c.beans[name]
+		if !beanDef.isAssignableTo(typ) {
+			continue
+		}
+		if qualifier != "" && !beanDef.HasQualifier(qualifier) {
+			continue
+		}
+		candidates = append(candidates, name)
+	}
 
-	return newInstance
+	switch len(candidates) {
+	case 0:
+		return "", &BeanNotFoundError{Type: typ, Qualifier: qualifier}
+	case 1:
+		return candidates[0], nil
+	default:
+		return c.selectCandidate(typ, candidates)
+	}
+}
+
+// parseInjectTag 解析inject标签，格式为 inject:"beanName,optional"
+// 名称为空或为 "true" 时按类型注入
+func parseInjectTag(tag string) (name string, optional bool) {
+	parts := strings.Split(tag, ",")
+	name = strings.TrimSpace(parts[0])
+	if name == "true" {
+		name = ""
+	}
+	for _, option := range parts[1:] {
+		if strings.TrimSpace(option) == "optional" {
+			optional = true
+		}
+	}
+	return name, optional
 }
 
 // InjectDependencies 执行依赖注入
+//
+// 严格模式下，任何无法解析或类型不匹配的非可选依赖都会导致返回 *WiringError；
+// 非严格模式下仅记录 DependencyInjectionFailed 事件。
+// value 标签的属性无法解析或转换时，两种模式下都返回 *WiringError。
 func (c *Container) InjectDependencies(instance interface{}) error {
+	return c.injectDependencies("", instance, creation{})
+}
+
+// injectDependencies 执行依赖注入，cr 为当前的创建过程
+func (c *Container) injectDependencies(beanName string, instance interface{}, cr creation) error {
+	failures := c.injectFields(beanName, instance, cr)
+	if len(failures) == 0 || !c.IsStrictWiring() && !hasValueInjectionError(failures) {
+		return nil
+	}
+	return &WiringError{Errors: failures}
+}
+
+// injectFields 为实例的inject字段注入依赖、为value字段注入属性值，返回所有注入失败的错误
+func (c *Container) injectFields(beanName string, instance interface{}, cr creation) []error {
 	val := reflect.ValueOf(instance)
 	if val.Kind() == reflect.Ptr {
 		val = val.Elem()
 	}
 
 	typ := val.Type()
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var failures []error
 
 	// 遍历所有字段
 	for i := 0; i < val.NumField(); i++ {
 		field := val.Field(i)
 		fieldType := typ.Field(i)
 
+		// value 标签从运行环境中解析属性值
+		if expression, ok := fieldType.Tag.Lookup("value"); ok {
+			if !field.CanSet() {
+				continue
+			}
+			if err := c.injectValue(field, expression); err != nil {
+				failures = append(failures, c.valueInjectionFailed(beanName, typ, fieldType, expression, err))
+			}
+			continue
+		}
+
 		// 检查inject标签
-		injectTag := fieldType.Tag.Get("inject")
-		if injectTag == "" {
+		injectTag, ok := fieldType.Tag.Lookup("inject")
+		if !ok {
 			continue
 		}
+		name, optional := parseInjectTag(injectTag)
+		qualifier := fieldType.Tag.Get("qualifier")
 
 		// 如果字段不可设置，跳过
 		if !field.CanSet() {
 			continue
 		}
 
+		// Provider 和 Lazy 字段只绑定到容器，调用时才解析目标Bean
+		if isDeferred(fieldType.Type) {
+			if err := c.bindDeferred(field, name, qualifier); err != nil && !optional {
+				failures = append(failures, c.unsatisfiedDependency(beanName, typ, fieldType, name, err))
+			}
+			continue
+		}
+
 		var dependency interface{}
+		var err error
 
 		// 如果标签指定了Bean名称
-		if injectTag != "" && injectTag != "true" {
-			dependency = c.GetBean(injectTag)
+		if name != "" {
+			dependency, err = c.getBean(name, cr)
+		} else if _, isCollection := collectionElem(fieldType.Type); isCollection {
+			// 切片或映射字段注入所有可赋值给元素类型的Bean
+			var collection reflect.Value
+			if collection, err = c.resolveCollection(fieldType.Type, qualifier, beanName, cr); err == nil {
+				dependency = collection.Interface()
+			}
 		} else {
 			// 根据类型查找
-			dependency = c.GetBeanByType(fieldType.Type)
+			dependency, err = c.getBeanByType(fieldType.Type, qualifier, cr)
 		}
 
-		if dependency != nil {
-			depVal := reflect.ValueOf(dependency)
-			if depVal.Type().AssignableTo(field.Type()) {
-				field.Set(depVal)
-				
-				// 记录依赖注入成功事件
-				c.logger.LogEvent(&logging.DependencyInjected{
-					Timestamp:      time.Now(),
-					TargetType:     typ.String(),
-					DependencyType: depVal.Type().String(),
-					FieldName:      fieldType.Name,
-					ByType:         injectTag == "" || injectTag == "true",
-					ByName:         injectTag != "" && injectTag != "true",
-				})
+		if err == nil {
+			if dependency == nil {
+				err = fmt.Errorf("dependency not found")
+			} else if depType := reflect.TypeOf(dependency); !depType.AssignableTo(field.Type()) {
+				err = fmt.Errorf("bean of type %v is not assignable to %v", depType, field.Type())
 			}
-		} else {
-			// 记录依赖注入失败事件
-			c.logger.LogEvent(&logging.DependencyInjectionFailed{
-				Timestamp:      time.Now(),
-				TargetType:     typ.String(),
-				DependencyType: fieldType.Type.String(),
-				FieldName:      fieldType.Name,
-				Error:          fmt.Errorf("dependency not found"),
-			})
 		}
+
+		if err != nil {
+			if !optional {
+				failures = append(failures, c.unsatisfiedDependency(beanName, typ, fieldType, name, err))
+			}
+			continue
+		}
+
+		depVal := reflect.ValueOf(dependency)
+		field.Set(depVal)
+
+		// 记录依赖注入成功事件
+		c.logger.LogEvent(&logging.DependencyInjected{
+			Timestamp:      time.Now(),
+			TargetType:     typ.String(),
+			DependencyType: depVal.Type().String(),
+			FieldName:      fieldType.Name,
+			ByType:         name == "",
+			ByName:         name != "",
+		})
 	}
 
-	return nil
+	return failures
+}
+
+// unsatisfiedDependency 记录依赖注入失败事件，并返回对应的 *UnsatisfiedDependencyError
+func (c *Container) unsatisfiedDependency(beanName string, typ reflect.Type, field reflect.StructField, name string, err error) error {
+	c.logger.LogEvent(&logging.DependencyInjectionFailed{
+		Timestamp:      time.Now(),
+		TargetType:     typ.String(),
+		DependencyType: field.Type.String(),
+		FieldName:      field.Name,
+		Error:          err,
+	})
+	return &UnsatisfiedDependencyError{
+		BeanName:       beanName,
+		BeanType:       typ,
+		FieldName:      field.Name,
+		DependencyName: name,
+		DependencyType: field.Type,
+		Cause:          err,
+	}
 }
 
-// WireAll 对所有已注册的Bean执行依赖注入
+// WireAll 按依赖顺序对所有已注册的Bean执行依赖注入
+//
+// 严格模式下会收集所有Bean的注入失败，并以一个 *WiringError 返回。
 func (c *Container) WireAll() error {
+	order, err := c.initializationOrder(true)
+	if err != nil {
+		c.logger.LogEvent(&logging.CircularDependencyDetected{
+			Timestamp: time.Now(),
+			Chain:     err.Error(),
+			Allowed:   false,
+		})
+		return err
+	}
+
 	c.mutex.RLock()
-	defer c.mutex.RUnlock()
+	beanDefs := make([]*BeanDefinition, 0, len(order))
+	for _, name := range order {
+		beanDefs = append(beanDefs, c.beans[name])
+	}
+	c.mutex.RUnlock()
+
+	// 先绑定配置属性，注入配置对象的Bean即可使用绑定后的值
+	if err := c.bindConfigProperties(beanDefs, false); err != nil {
+		return err
+	}
+
+	var failures []error
+
+	// 先实例化所有通过构造函数注册的单例，构造函数结果在创建时已完成注入
+	// 延迟Bean在首次获取时才创建和注入，被非延迟Bean通过 dependsOn 依赖时随之创建
+	for _, beanDef := range beanDefs {
+		if beanDef.Lazy {
+			continue
+		}
+		for _, name := range beanDef.DependsOn {
+			if dep := c.GetBeanDefinition(name); dep != nil && dep.Lazy {
+				if _, err := c.getBean(name, creation{}); err != nil {
+					failures = append(failures, err)
+				}
+			}
+		}
+		if beanDef.Singleton && beanDef.factory.IsValid() {
+			if _, err := c.getOrCreateSingleton(beanDef, creation{}); err != nil {
+				failures = append(failures, err)
+			}
+		}
+	}
 
-	for _, beanDef := range c.beans {
-		if err := c.InjectDependencies(beanDef.Instance); err != nil {
-			return fmt.Errorf("failed to inject dependencies for bean '%s': %v", beanDef.Name, err)
+	// 原型和作用域Bean在每次创建实例时注入，注册的实例只作为模板
+	for _, beanDef := range beanDefs {
+		if !beanDef.Singleton || beanDef.factory.IsValid() || beanDef.Lazy {
+			continue
 		}
+		failures = append(failures, c.injectFields(beanDef.Name, beanDef.Instance, creation{})...)
+	}
+
+	if len(failures) > 0 && (c.IsStrictWiring() || hasValueInjectionError(failures)) {
+		return &WiringError{Errors: flattenWiringErrors(failures)}
 	}
 
 	return nil
 }
 
-// ListBeans 列出所有注册的Bean
+// ListBeans 按注册顺序列出本容器中注册的Bean，不包括父容器
 func (c *Container) ListBeans() []string {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
-	var names []string
-	for name := range c.beans {
-		names = append(names, name)
-	}
+	names := make([]string, len(c.order))
+	copy(names, c.order)
 
 	return names
 }
 
-// HasBean 检查是否存在指定名称的Bean
-func (c *Container) HasBean(name string) bool {
+// GetBeanNamesForType 返回本容器中可赋值给指定类型的Bean名称
+//
+// 名称按排序值排列，排序值相同时保持注册顺序。
+func (c *Container) GetBeanNamesForType(typ reflect.Type) []string {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
-	_, exists := c.beans[name]
-	return exists
+	var names []string
+	for _, name := range c.order {
+		if c.beans[name].isAssignableTo(typ) {
+			names = append(names, name)
+		}
+	}
+	c.sortByOrder(names)
+	return names
+}
+
+// GetBeansOfType 获取本容器中可赋值给指定类型的Bean
+//
+// 返回的映射没有顺序，不反映排序值；需要按排序值遍历时使用 GetBeanNamesForType 返回的名称顺序，或 ResolveAll。
+func (c *Container) GetBeansOfType(typ reflect.Type) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, name := range c.GetBeanNamesForType(typ) {
+		if bean := c.GetBean(name); bean != nil {
+			result[name] = bean
+		}
+	}
+	return result
+}
+
+// isAssignableTo 检查Bean实例是否可赋值给指定类型，以结构体类型注册的映射同样匹配
+func (beanDef *BeanDefinition) isAssignableTo(typ reflect.Type) bool {
+	return beanDef.instanceType.AssignableTo(typ) || beanDef.Type == typ
 }
 
-// GetBeanDefinition 获取Bean定义
+// HasBean 检查本容器或父容器中是否存在指定名称的Bean
+func (c *Container) HasBean(name string) bool {
+	if c.HasLocalBean(name) {
+		return true
+	}
+	if parent := c.GetParent(); parent != nil {
+		return parent.HasBean(name)
+	}
+	return false
+}
+
+// GetBeanDefinition 按名称或别名获取Bean定义
 func (c *Container) GetBeanDefinition(name string) *BeanDefinition {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
-	return c.beans[name]
+	return c.beans[c.canonicalName(name)]
 }
 
 // RegisterByInterface 根据接口注册实现
-func (c *Container) RegisterByInterface(interfaceType reflect.Type, implementation interface{}, name string) error {
+//
+// 本容器中没有其他Bean可以赋值给该接口时，接口直接映射到该实现；
+// 否则按类型解析时在所有实现中选择唯一的首选Bean，没有时返回 *NoUniqueBeanError。
+func (c *Container) RegisterByInterface(interfaceType reflect.Type, implementation interface{}, name string, opts ...BeanOption) error {
 	implType := reflect.TypeOf(implementation)
 	
 	// 检查是否实现了接口
@@ -292,18 +608,63 @@ func (c *Container) RegisterByInterface(interfaceType reflect.Type, implementati
 	}
 
 	// 注册实现
-	if err := c.RegisterSingleton(name, implementation); err != nil {
+	if err := c.RegisterSingleton(name, implementation, opts...); err != nil {
 		return err
 	}
 
-	// 注册接口映射
+	// 注册接口映射，已有其他实现时由 findBeanNameForType 按首选Bean和歧义规则选择
 	c.mutex.Lock()
-	c.typeMapping[interfaceType] = name
-	c.mutex.Unlock()
+	defer c.mutex.Unlock()
 
+	if _, exists := c.beans[name]; !exists {
+		return nil
+	}
+	for _, other := range c.order {
+		if other != name && c.beans[other].isAssignableTo(interfaceType) {
+			delete(c.typeMapping, interfaceType)
+			delete(c.autoBound, interfaceType)
+			return nil
+		}
+	}
+	c.typeMapping[interfaceType] = name
+	delete(c.autoBound, interfaceType)
 	return nil
 }
 
+// SetAllowCircularReferences 设置是否允许单例Bean之间通过字段注入形成循环依赖
+//
+// 开启后，仅由已存在实例的单例组成的循环会被放行，并以 CircularDependencyDetected 事件告警；
+// 涉及原型Bean或构造函数参数的循环仍然会导致装配失败。
+func (c *Container) SetAllowCircularReferences(allow bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.allowCircularReferences = allow
+}
+
+// IsAllowCircularReferences 是否允许单例Bean之间的循环依赖
+func (c *Container) IsAllowCircularReferences() bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.allowCircularReferences
+}
+
+// SetStrictWiring 设置是否启用严格装配模式（默认启用）
+//
+// 严格模式下，无法解析或类型不匹配的非可选依赖会使装配失败，
+// 使用 inject:"name,optional" 标记允许保持为nil的字段。
+func (c *Container) SetStrictWiring(strict bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.strictWiring = strict
+}
+
+// IsStrictWiring 是否启用严格装配模式
+func (c *Container) IsStrictWiring() bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.strictWiring
+}
+
 // SetLogger 设置容器的日志器
 func (c *Container) SetLogger(logger logging.Logger) {
 	c.mutex.Lock()
@@ -320,11 +681,14 @@ func (c *Container) GetLogger() logging.Logger {
 
 // Destroy 销毁容器，清理资源
 func (c *Container) Destroy() {
+	c.RefreshScope()
+
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	// 调用所有Bean的销毁方法（如果有的话）
-	for _, beanDef := range c.beans {
+	// 按注册顺序的逆序调用所有Bean的销毁方法（如果有的话）
+	for i := len(c.order) - 1; i >= 0; i-- {
+		beanDef := c.beans[c.order[i]]
 		if destroyer, ok := beanDef.Instance.(interface{ Destroy() }); ok {
 			destroyer.Destroy()
 		}
@@ -339,5 +703,8 @@ func (c *Container) Destroy() {
 
 	// 清理映射
 	c.beans = make(map[string]*BeanDefinition)
+	c.order = nil
 	c.typeMapping = make(map[reflect.Type]string)
+	c.autoBound = make(map[reflect.Type]bool)
+	c.aliases = make(map[string]string)
 }
\ No newline at end of file
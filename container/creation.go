@@ -0,0 +1,107 @@
+package container
+
+import "context"
+
+// creation 描述一次Bean解析过程
+type creation struct {
+	ctx   context.Context // 请求作用域所在的上下文，可能为nil
+	chain []string        // 当前的创建链，用于检测构造过程中的循环
+	owner *resolution     // 当前解析，首次创建单例时分配
+}
+
+// enter 返回进入指定Bean创建过程后的解析状态，不修改原有的创建链
+func (cr creation) enter(name string) creation {
+	cr.chain = append(cr.chain[:len(cr.chain):len(cr.chain)], name)
+	return cr
+}
+
+// isCreating 检查Bean是否已在当前的创建链中
+func (cr creation) isCreating(name string) bool {
+	for _, creating := range cr.chain {
+		if creating == name {
+			return true
+		}
+	}
+	return false
+}
+
+// resolution 一次从外部发起的解析，创建链上的单例都在同一个协程中创建
+type resolution struct {
+	waitingFor *singletonCreation // 正在等待其他解析完成的单例创建
+	waitChain  []string           // 开始等待时的创建链
+}
+
+// singletonCreation 正在进行的单例创建
+type singletonCreation struct {
+	name  string
+	owner *resolution
+	done  chan struct{}
+}
+
+// createSingleton 保证单例只创建一次，create 执行期间不持有任何锁
+//
+// created 检查单例是否已经创建。其他解析正在创建同一个单例时等待其完成，
+// 完成后仍未创建（创建失败）时由当前解析重新创建；等待会与其他解析互相等待时返回 *CircularDependencyError。
+func (c *Container) createSingleton(beanDef *BeanDefinition, cr creation, created func() bool, create func(creation) error) error {
+	if cr.owner == nil {
+		cr.owner = &resolution{}
+	}
+
+	for {
+		c.creatingMutex.Lock()
+		if created() {
+			c.creatingMutex.Unlock()
+			return nil
+		}
+
+		pending, exists := c.creating[beanDef]
+		if !exists {
+			pending = &singletonCreation{name: beanDef.Name, owner: cr.owner, done: make(chan struct{})}
+			c.creating[beanDef] = pending
+			c.creatingMutex.Unlock()
+
+			err := create(cr)
+
+			c.creatingMutex.Lock()
+			delete(c.creating, beanDef)
+			close(pending.done)
+			c.creatingMutex.Unlock()
+			return err
+		}
+
+		if chain, deadlock := waitCycle(cr, pending); deadlock {
+			c.creatingMutex.Unlock()
+			return newCreationCycleError(chain, beanDef.Name)
+		}
+		cr.owner.waitingFor, cr.owner.waitChain = pending, cr.chain
+		c.creatingMutex.Unlock()
+
+		<-pending.done
+
+		c.creatingMutex.Lock()
+		cr.owner.waitingFor, cr.owner.waitChain = nil, nil
+		c.creatingMutex.Unlock()
+	}
+}
+
+// waitCycle 检查等待 pending 是否会形成互相等待，是则返回从 pending 开始的创建链，调用方需持有 creatingMutex
+func waitCycle(cr creation, pending *singletonCreation) ([]string, bool) {
+	var chain []string
+	for p := pending; p != nil; p = p.owner.waitingFor {
+		if p.owner == cr.owner {
+			return append(chain, chainFrom(cr.chain, p.name)...), true
+		}
+		chain = append(chain, chainFrom(p.owner.waitChain, p.name)...)
+	}
+	return nil, false
+}
+
+// chainFrom 返回创建链中从指定Bean开始的部分
+func chainFrom(chain []string, name string) []string {
+	for i, creating := range chain {
+		if creating == name {
+			return chain[i:]
+		}
+	}
+	return []string{name}
+}
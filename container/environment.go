@@ -0,0 +1,74 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gospring/environment"
+	"gospring/logging"
+)
+
+var environmentType = reflect.TypeOf((*environment.Environment)(nil)).Elem()
+
+// SetEnvironment 设置容器的运行环境，注册时根据其中激活的配置文件判断Bean的 profile 声明
+//
+// 运行环境同时作为可解析的依赖，可以按 environment.Environment 接口或其具体类型注入。
+func (c *Container) SetEnvironment(env environment.Environment) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.environment != nil {
+		delete(c.resolvable, reflect.TypeOf(c.environment))
+	}
+	c.environment = env
+	c.resolvable[environmentType] = env
+	c.resolvable[reflect.TypeOf(env)] = env
+}
+
+// GetEnvironment 获取容器的运行环境
+func (c *Container) GetEnvironment() environment.Environment {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.environment
+}
+
+// RegisterResolvableDependency 注册按类型注入时可解析、但不作为Bean管理的对象
+//
+// 只有注入点的类型与 typ 完全相同时才会使用，且本容器中没有该类型的Bean时才生效。
+// 这类对象不参与生命周期，也不会出现在 ListBeans 中。
+func (c *Container) RegisterResolvableDependency(typ reflect.Type, value interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.resolvable[typ] = value
+}
+
+// resolvableDependency 获取可解析的依赖
+func (c *Container) resolvableDependency(typ reflect.Type) (interface{}, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	value, exists := c.resolvable[typ]
+	return value, exists
+}
+
+// acceptsProfiles 检查Bean声明的配置文件是否成立，不成立时记录 BeanSkipped 事件，调用方需持有锁
+func (c *Container) acceptsProfiles(beanDef *BeanDefinition) bool {
+	if len(beanDef.Profiles) == 0 || c.environment.AcceptsProfiles(beanDef.Profiles...) {
+		return true
+	}
+
+	c.logger.LogEvent(&logging.BeanSkipped{
+		Timestamp:     time.Now(),
+		ComponentID:   beanDef.Name,
+		ComponentType: beanDef.Type.String(),
+		Condition:     profileDescription(beanDef.Profiles),
+	})
+	return false
+}
+
+// profileDescription 配置文件表达式的说明
+func profileDescription(profiles []string) string {
+	return fmt.Sprintf("profile %s is active", strings.Join(profiles, " or "))
+}
@@ -0,0 +1,273 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// 用于 errors.Is 判断的错误类别，具体错误类型携带Bean名称、类型和字段等信息
+var (
+	ErrBeanNotFound          = errors.New("bean not found")
+	ErrDuplicateBean         = errors.New("duplicate bean")
+	ErrBeanNotOfRequiredType = errors.New("bean not of required type")
+	ErrNoUniqueBean          = errors.New("no unique bean")
+	ErrBeanCreation          = errors.New("bean creation failed")
+	ErrUnsatisfiedDependency = errors.New("unsatisfied dependency")
+	ErrCircularDependency    = errors.New("circular dependency")
+	ErrScopeNotActive        = errors.New("scope not active")
+	ErrValueInjection        = errors.New("value injection failed")
+)
+
+// BeanNotFoundError 找不到Bean错误，按名称查找时 Name 非空，按类型查找时 Type 非空
+type BeanNotFoundError struct {
+	Name      string
+	Type      reflect.Type
+	Qualifier string // 按限定符查找时非空
+}
+
+func (e *BeanNotFoundError) Error() string {
+	if e.Name != "" {
+		return fmt.Sprintf("bean with name '%s' not found", e.Name)
+	}
+	if e.Qualifier != "" {
+		return fmt.Sprintf("no bean of type %v with qualifier '%s' found", e.Type, e.Qualifier)
+	}
+	return fmt.Sprintf("no bean of type %v found", e.Type)
+}
+
+func (e *BeanNotFoundError) Is(target error) bool {
+	return target == ErrBeanNotFound
+}
+
+// NoUniqueBeanError 按类型查找时存在多个候选Bean错误
+type NoUniqueBeanError struct {
+	Type       reflect.Type
+	Candidates []string
+}
+
+func (e *NoUniqueBeanError) Error() string {
+	return fmt.Sprintf("expected single bean of type %v but found %d: %s",
+		e.Type, len(e.Candidates), strings.Join(e.Candidates, ", "))
+}
+
+func (e *NoUniqueBeanError) Is(target error) bool {
+	return target == ErrNoUniqueBean
+}
+
+// DuplicateBeanError 重复注册Bean错误
+type DuplicateBeanError struct {
+	Name         string
+	ExistingType reflect.Type // 已注册Bean的类型
+}
+
+func (e *DuplicateBeanError) Error() string {
+	return fmt.Sprintf("bean with name '%s' already exists", e.Name)
+}
+
+func (e *DuplicateBeanError) Is(target error) bool {
+	return target == ErrDuplicateBean
+}
+
+// BeanNotOfRequiredTypeError Bean类型与要求的类型不匹配错误
+type BeanNotOfRequiredTypeError struct {
+	RequiredType reflect.Type
+	ActualType   reflect.Type
+}
+
+func (e *BeanNotOfRequiredTypeError) Error() string {
+	return fmt.Sprintf("bean of type %v is not assignable to %v", e.ActualType, e.RequiredType)
+}
+
+func (e *BeanNotOfRequiredTypeError) Is(target error) bool {
+	return target == ErrBeanNotOfRequiredType
+}
+
+// BeanCreationError Bean创建失败错误
+type BeanCreationError struct {
+	Name  string
+	Type  reflect.Type
+	Cause error
+}
+
+func (e *BeanCreationError) Error() string {
+	return fmt.Sprintf("failed to create bean '%s' (%v): %v", e.Name, e.Type, e.Cause)
+}
+
+func (e *BeanCreationError) Is(target error) bool {
+	return target == ErrBeanCreation
+}
+
+func (e *BeanCreationError) Unwrap() error {
+	return e.Cause
+}
+
+// ScopeNotActiveError 获取作用域Bean时作用域未注册或未开启
+//
+// 请求作用域只在 OpenRequestScope 返回的上下文中有效，单例需要通过
+// Provider[T] 的 GetContext 在请求处理时解析请求作用域Bean。
+type ScopeNotActiveError struct {
+	Scope    string
+	BeanName string
+}
+
+func (e *ScopeNotActiveError) Error() string {
+	if e.Scope == ScopeRequest {
+		return fmt.Sprintf("scope '%s' is not active for bean '%s'; resolve it with a request context, e.g. through container.Provider[T].GetContext", e.Scope, e.BeanName)
+	}
+	return fmt.Sprintf("no scope registered with name '%s' for bean '%s'", e.Scope, e.BeanName)
+}
+
+func (e *ScopeNotActiveError) Is(target error) bool {
+	return target == ErrScopeNotActive
+}
+
+// DependencyLink 依赖链中的一环
+type DependencyLink struct {
+	BeanName  string // Bean名称
+	FieldName string // 指向下一个Bean的字段或构造函数参数，链尾为空
+}
+
+// CircularDependencyError 循环依赖错误，Chain 的首尾为同一个Bean
+type CircularDependencyError struct {
+	Chain []DependencyLink
+}
+
+func (e *CircularDependencyError) Error() string {
+	parts := make([]string, len(e.Chain))
+	for i, link := range e.Chain {
+		if link.FieldName != "" {
+			parts[i] = fmt.Sprintf("%s (field %s)", link.BeanName, link.FieldName)
+		} else {
+			parts[i] = link.BeanName
+		}
+	}
+	return "circular dependency detected: " + strings.Join(parts, " -> ")
+}
+
+func (e *CircularDependencyError) Is(target error) bool {
+	return target == ErrCircularDependency
+}
+
+// BeanNames 返回依赖链上的Bean名称
+func (e *CircularDependencyError) BeanNames() []string {
+	names := make([]string, len(e.Chain))
+	for i, link := range e.Chain {
+		names[i] = link.BeanName
+	}
+	return names
+}
+
+// newCreationCycleError 根据创建链构造循环依赖错误
+func newCreationCycleError(chain []string, name string) *CircularDependencyError {
+	start := 0
+	for i, creating := range chain {
+		if creating == name {
+			start = i
+			break
+		}
+	}
+
+	links := make([]DependencyLink, 0, len(chain)-start+1)
+	for _, creating := range chain[start:] {
+		links = append(links, DependencyLink{BeanName: creating})
+	}
+	links = append(links, DependencyLink{BeanName: name})
+
+	return &CircularDependencyError{Chain: links}
+}
+
+// UnsatisfiedDependencyError 依赖无法满足错误
+type UnsatisfiedDependencyError struct {
+	BeanName       string       // 需要注入的Bean名称，外部对象为空
+	BeanType       reflect.Type // 需要注入的Bean类型
+	FieldName      string       // 注入字段，构造函数参数为 argN
+	DependencyName string       // 按名称注入时的依赖名称
+	DependencyType reflect.Type // 字段类型，dependsOn 声明的依赖为nil
+	Cause          error
+}
+
+func (e *UnsatisfiedDependencyError) Error() string {
+	target := e.BeanType.String()
+	if e.BeanName != "" {
+		target = fmt.Sprintf("bean '%s' (%v)", e.BeanName, e.BeanType)
+	}
+
+	dependency := fmt.Sprint(e.DependencyType)
+	if e.DependencyName != "" && e.DependencyType == nil {
+		dependency = fmt.Sprintf("'%s'", e.DependencyName)
+	} else if e.DependencyName != "" {
+		dependency = fmt.Sprintf("'%s' (%v)", e.DependencyName, e.DependencyType)
+	}
+
+	return fmt.Sprintf("unsatisfied dependency %s for field %s of %s: %v",
+		dependency, e.FieldName, target, e.Cause)
+}
+
+func (e *UnsatisfiedDependencyError) Is(target error) bool {
+	return target == ErrUnsatisfiedDependency
+}
+
+func (e *UnsatisfiedDependencyError) Unwrap() error {
+	return e.Cause
+}
+
+// ValueInjectionError value 标签的属性值无法解析或转换
+type ValueInjectionError struct {
+	BeanName   string       // 需要注入的Bean名称，外部对象为空
+	BeanType   reflect.Type // 需要注入的Bean类型
+	FieldName  string       // 注入字段
+	Expression string       // value 标签中的表达式
+	Key        string       // 无法解析或转换的属性名
+	Cause      error
+}
+
+func (e *ValueInjectionError) Error() string {
+	target := e.BeanType.String()
+	if e.BeanName != "" {
+		target = fmt.Sprintf("bean '%s' (%v)", e.BeanName, e.BeanType)
+	}
+	return fmt.Sprintf("cannot inject property '%s' (%s) into field %s of %s: %v",
+		e.Key, e.Expression, e.FieldName, target, e.Cause)
+}
+
+func (e *ValueInjectionError) Is(target error) bool {
+	return target == ErrValueInjection
+}
+
+func (e *ValueInjectionError) Unwrap() error {
+	return e.Cause
+}
+
+// WiringError 装配错误，汇总所有Bean和字段的注入失败
+type WiringError struct {
+	Errors []error
+}
+
+func (e *WiringError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "failed to wire %d dependencies:", len(e.Errors))
+	for _, err := range e.Errors {
+		sb.WriteString("\n  - ")
+		sb.WriteString(err.Error())
+	}
+	return sb.String()
+}
+
+func (e *WiringError) Unwrap() []error {
+	return e.Errors
+}
+
+// flattenWiringErrors 展开嵌套的 WiringError，便于汇总
+func flattenWiringErrors(errs []error) []error {
+	var flat []error
+	for _, err := range errs {
+		if wiringErr, ok := err.(*WiringError); ok {
+			flat = append(flat, flattenWiringErrors(wiringErr.Errors)...)
+			continue
+		}
+		flat = append(flat, err)
+	}
+	return flat
+}
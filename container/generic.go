@@ -0,0 +1,110 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// BeanFactory 可按名称和类型解析Bean，Container 和 ApplicationContext 都实现了该接口
+type BeanFactory interface {
+	LookupBean(name string) (interface{}, error)
+	LookupBeanByType(typ reflect.Type) (interface{}, error)
+	LookupQualifiedBean(typ reflect.Type, qualifier string) (interface{}, error)
+	GetBeanNamesForType(typ reflect.Type) []string
+}
+
+// BeanRegistry 可注册Bean，Container 和 ApplicationContext 都实现了该接口
+type BeanRegistry interface {
+	RegisterSingleton(name string, instance interface{}, opts ...BeanOption) error
+	RegisterByInterface(interfaceType reflect.Type, implementation interface{}, name string, opts ...BeanOption) error
+}
+
+// TypeOf 返回类型参数对应的 reflect.Type，接口类型同样适用
+func TypeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// Register 注册单例Bean，T 为接口时同时将该接口绑定到此Bean
+func Register[T any](r BeanRegistry, name string, instance T, opts ...BeanOption) error {
+	typ := TypeOf[T]()
+	if typ.Kind() == reflect.Interface {
+		return r.RegisterByInterface(typ, instance, name, opts...)
+	}
+	return r.RegisterSingleton(name, instance, opts...)
+}
+
+// Bind 注册接口 I 的实现 Impl，之后可通过 Resolve[I] 获取
+func Bind[I any, Impl any](r BeanRegistry, name string, impl Impl, opts ...BeanOption) error {
+	interfaceType := TypeOf[I]()
+	if interfaceType.Kind() != reflect.Interface {
+		return fmt.Errorf("cannot bind %v: not an interface type", interfaceType)
+	}
+	return r.RegisterByInterface(interfaceType, impl, name, opts...)
+}
+
+// Resolve 按类型参数解析Bean，T 可以是接口或结构体指针类型
+func Resolve[T any](f BeanFactory) (T, error) {
+	typ := TypeOf[T]()
+	bean, err := f.LookupBeanByType(typ)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return convertBean[T](bean, typ)
+}
+
+// ResolveQualified 按类型参数和限定符解析Bean，用于同类型存在多个Bean的场景
+func ResolveQualified[T any](f BeanFactory, qualifier string) (T, error) {
+	typ := TypeOf[T]()
+	bean, err := f.LookupQualifiedBean(typ, qualifier)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return convertBean[T](bean, typ)
+}
+
+// ResolveNamed 按名称解析Bean，并检查其类型是否为 T
+func ResolveNamed[T any](f BeanFactory, name string) (T, error) {
+	bean, err := f.LookupBean(name)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return convertBean[T](bean, TypeOf[T]())
+}
+
+// MustResolve 按类型参数解析Bean，失败时panic，适用于启动阶段
+func MustResolve[T any](f BeanFactory) T {
+	bean, err := Resolve[T](f)
+	if err != nil {
+		panic(err)
+	}
+	return bean
+}
+
+// ResolveAll 按排序值解析所有可赋值给 T 的Bean，排序值相同时按注册顺序
+func ResolveAll[T any](f BeanFactory) ([]T, error) {
+	typ := TypeOf[T]()
+	names := f.GetBeanNamesForType(typ)
+
+	beans := make([]T, 0, len(names))
+	for _, name := range names {
+		bean, err := ResolveNamed[T](f, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve bean '%s': %w", name, err)
+		}
+		beans = append(beans, bean)
+	}
+	return beans, nil
+}
+
+// convertBean 将Bean转换为类型 T
+func convertBean[T any](bean interface{}, typ reflect.Type) (T, error) {
+	if result, ok := bean.(T); ok {
+		return result, nil
+	}
+
+	var zero T
+	return zero, &BeanNotOfRequiredTypeError{RequiredType: typ, ActualType: reflect.TypeOf(bean)}
+}
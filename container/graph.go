@@ -0,0 +1,218 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"gospring/logging"
+)
+
+// dependency 描述Bean对另一个Bean的依赖
+type dependency struct {
+	Name  string // 被依赖的Bean名称
+	Field string // 声明依赖的字段或构造函数参数
+}
+
+// GetDependencies 获取Bean直接依赖的Bean名称
+func (c *Container) GetDependencies(name string) []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	beanDef, exists := c.beans[c.canonicalName(name)]
+	if !exists {
+		return nil
+	}
+
+	var names []string
+	for _, dep := range c.dependenciesOf(beanDef) {
+		names = append(names, dep.Name)
+	}
+	return names
+}
+
+// dependenciesOf 解析Bean声明的依赖，调用方需持有读锁
+func (c *Container) dependenciesOf(beanDef *BeanDefinition) []dependency {
+	var deps []dependency
+
+	// dependsOn 声明的依赖，父容器中的Bean由父容器初始化，不参与排序
+	for _, name := range beanDef.DependsOn {
+		if _, exists := c.beans[c.canonicalName(name)]; exists {
+			deps = append(deps, dependency{Name: c.canonicalName(name), Field: "dependsOn"})
+		}
+	}
+
+	// 构造函数参数
+	if beanDef.factory.IsValid() {
+		fnType := beanDef.factory.Type()
+		for i := 0; i < fnType.NumIn(); i++ {
+			if isDeferred(fnType.In(i)) {
+				continue
+			}
+			if name, err := c.findBeanNameForType(fnType.In(i), ""); err == nil {
+				deps = append(deps, dependency{Name: name, Field: fmt.Sprintf("arg%d", i)})
+			}
+		}
+	}
+
+	// inject 标签声明的字段
+	if beanDef.Type.Kind() != reflect.Struct {
+		return deps
+	}
+	for i := 0; i < beanDef.Type.NumField(); i++ {
+		field := beanDef.Type.Field(i)
+		injectTag, ok := field.Tag.Lookup("inject")
+		if !ok || isDeferred(field.Type) {
+			continue
+		}
+
+		if name, _ := parseInjectTag(injectTag); name != "" {
+			if _, exists := c.beans[c.canonicalName(name)]; exists {
+				deps = append(deps, dependency{Name: c.canonicalName(name), Field: field.Name})
+			}
+			continue
+		}
+		if elemType, isCollection := collectionElem(field.Type); isCollection {
+			for _, name := range c.collectionCandidates(elemType, field.Tag.Get("qualifier"), beanDef.Name) {
+				deps = append(deps, dependency{Name: name, Field: field.Name})
+			}
+			continue
+		}
+		if name, err := c.findBeanNameForType(field.Type, field.Tag.Get("qualifier")); err == nil {
+			deps = append(deps, dependency{Name: name, Field: field.Name})
+		}
+	}
+
+	return deps
+}
+
+// InitializationOrder 按依赖关系计算Bean的初始化顺序
+//
+// 被依赖的Bean（包括 dependsOn 声明的Bean）总是排在依赖它的Bean之前，
+// 相互独立的Bean按排序值排列，排序值相同时保持注册顺序，因此结果是确定的。销毁时应按该顺序的逆序进行。
+// 存在循环依赖时返回 *CircularDependencyError。
+func (c *Container) InitializationOrder() ([]string, error) {
+	return c.initializationOrder(false)
+}
+
+// initializationOrder 计算初始化顺序，report 为 true 时对放行的循环记录告警事件
+func (c *Container) initializationOrder(report bool) ([]string, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int, len(c.beans))
+	order := make([]string, 0, len(c.beans))
+	var path []DependencyLink
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		if err := c.checkDependsOn(c.beans[name]); err != nil {
+			return err
+		}
+		state[name] = visiting
+		path = append(path, DependencyLink{BeanName: name})
+
+		for _, dep := range c.dependenciesOf(c.beans[name]) {
+			path[len(path)-1].FieldName = dep.Field
+
+			switch state[dep.Name] {
+			case visited:
+				continue
+			case visiting:
+				cycle := c.cycleFrom(path, dep.Name)
+				if !c.isCycleAllowed(cycle) {
+					return cycle
+				}
+				if report {
+					c.logger.LogEvent(&logging.CircularDependencyDetected{
+						Timestamp: time.Now(),
+						Chain:     cycle.Error(),
+						Allowed:   true,
+					})
+				}
+				continue
+			}
+
+			if err := visit(dep.Name); err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[name] = visited
+		order = append(order, name)
+		return nil
+	}
+
+	roots := make([]string, len(c.order))
+	copy(roots, c.order)
+	c.sortByOrder(roots)
+
+	for _, name := range roots {
+		if state[name] != unvisited {
+			continue
+		}
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+// checkDependsOn 检查 dependsOn 声明的Bean是否存在于本容器或父容器中，调用方需持有读锁
+func (c *Container) checkDependsOn(beanDef *BeanDefinition) error {
+	for _, name := range beanDef.DependsOn {
+		if _, exists := c.beans[c.canonicalName(name)]; exists {
+			continue
+		}
+		if c.parent != nil && c.parent.HasBean(name) {
+			continue
+		}
+		return &UnsatisfiedDependencyError{
+			BeanName:       beanDef.Name,
+			BeanType:       beanDef.Type,
+			FieldName:      "dependsOn",
+			DependencyName: name,
+			Cause:          &BeanNotFoundError{Name: name},
+		}
+	}
+	return nil
+}
+
+// cycleFrom 从当前访问路径中截取以 name 开始的循环
+func (c *Container) cycleFrom(path []DependencyLink, name string) *CircularDependencyError {
+	start := 0
+	for i, link := range path {
+		if link.BeanName == name {
+			start = i
+			break
+		}
+	}
+
+	chain := make([]DependencyLink, 0, len(path)-start+1)
+	chain = append(chain, path[start:]...)
+	chain = append(chain, DependencyLink{BeanName: name})
+	return &CircularDependencyError{Chain: chain}
+}
+
+// isCycleAllowed 检查循环是否可以放行：仅当开启了循环引用且循环中的Bean都是已存在实例的单例时
+func (c *Container) isCycleAllowed(cycle *CircularDependencyError) bool {
+	if !c.allowCircularReferences {
+		return false
+	}
+
+	for _, link := range cycle.Chain {
+		beanDef := c.beans[link.BeanName]
+		if !beanDef.Singleton || beanDef.factory.IsValid() {
+			return false
+		}
+	}
+	return true
+}
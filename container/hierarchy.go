@@ -0,0 +1,113 @@
+package container
+
+import (
+	"reflect"
+)
+
+// SetParent 设置父容器
+//
+// 本容器中找不到的Bean会继续在父容器中查找，同名的本地Bean覆盖父容器中的Bean。
+// 父容器中的Bean由父容器创建、注入和管理生命周期，看不到子容器中的Bean。
+func (c *Container) SetParent(parent *Container) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.parent = parent
+}
+
+// GetParent 获取父容器，没有父容器时返回nil
+func (c *Container) GetParent() *Container {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.parent
+}
+
+// NewChildContainer 创建以 parent 为父容器的容器，使用父容器的日志器
+func NewChildContainer(parent *Container) *Container {
+	child := NewContainerWithLogger(parent.GetLogger())
+	child.SetParent(parent)
+	return child
+}
+
+// HasLocalBean 检查本容器中是否存在指定名称的Bean，不查找父容器
+func (c *Container) HasLocalBean(name string) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	_, exists := c.beans[c.canonicalName(name)]
+	return exists
+}
+
+// ListBeansIncludingAncestors 列出本容器及所有父容器中的Bean名称
+//
+// 本容器的Bean在前，被本地Bean覆盖的父容器Bean只出现一次。
+func (c *Container) ListBeansIncludingAncestors() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for current := c; current != nil; current = current.GetParent() {
+		for _, name := range current.ListBeans() {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// GetBeanNamesForTypeIncludingAncestors 返回本容器及所有父容器中可赋值给指定类型的Bean名称
+func (c *Container) GetBeanNamesForTypeIncludingAncestors(typ reflect.Type) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for current := c; current != nil; current = current.GetParent() {
+		for _, name := range current.ListBeans() {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			if current.GetBeanDefinition(name).isAssignableTo(typ) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// GetBeansOfTypeIncludingAncestors 获取本容器及所有父容器中可赋值给指定类型的Bean
+func (c *Container) GetBeansOfTypeIncludingAncestors(typ reflect.Type) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, name := range c.GetBeanNamesForTypeIncludingAncestors(typ) {
+		if bean := c.GetBean(name); bean != nil {
+			result[name] = bean
+		}
+	}
+	return result
+}
+
+// ancestorCollectionCandidates 查找父容器中集合注入的候选Bean，跳过被本容器覆盖的名称
+func (c *Container) ancestorCollectionCandidates(elemType reflect.Type, qualifier string) []*BeanDefinition {
+	var beanDefs []*BeanDefinition
+	shadowed := make(map[string]bool)
+	for _, name := range c.ListBeans() {
+		shadowed[name] = true
+	}
+
+	for ancestor := c.GetParent(); ancestor != nil; ancestor = ancestor.GetParent() {
+		ancestor.mutex.RLock()
+		for _, name := range ancestor.collectionCandidates(elemType, qualifier, "") {
+			if !shadowed[name] {
+				beanDefs = append(beanDefs, ancestor.beans[name])
+			}
+		}
+		for _, name := range ancestor.order {
+			shadowed[name] = true
+		}
+		ancestor.mutex.RUnlock()
+	}
+	return beanDefs
+}
+
+// isBeanNotFound 检查按名称或类型查找是否没有找到候选，不包括依赖链中更深层的缺失
+func isBeanNotFound(err error) bool {
+	_, notFound := err.(*BeanNotFoundError)
+	return notFound
+}
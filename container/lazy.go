@@ -0,0 +1,204 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// IsLazyInitialized 检查延迟Bean是否已经完成初始化，非延迟Bean总是返回 false
+func (beanDef *BeanDefinition) IsLazyInitialized() bool {
+	beanDef.mutex.RLock()
+	defer beanDef.mutex.RUnlock()
+	return beanDef.lazyInitialized
+}
+
+// getLazySingleton 获取延迟单例，首次访问时创建、注入依赖并执行初始化回调
+func (c *Container) getLazySingleton(beanDef *BeanDefinition, cr creation) (interface{}, error) {
+	err := c.createSingleton(beanDef, cr, beanDef.IsLazyInitialized, func(cr creation) error {
+		if beanDef.factory.IsValid() {
+			// 初始化回调失败时保留已创建的实例，再次获取时只重新执行初始化
+			if !beanDef.hasInstance() {
+				instance, err := c.invokeFactory(beanDef, cr)
+				if err != nil {
+					return err
+				}
+				beanDef.setInstance(instance)
+			}
+		} else {
+			cr = cr.enter(beanDef.Name)
+			if err := c.injectDependencies(beanDef.Name, beanDef.Instance, cr); err != nil {
+				return &BeanCreationError{Name: beanDef.Name, Type: beanDef.Type, Cause: err}
+			}
+		}
+
+		if processor := c.getLifecycleProcessor(); processor != nil {
+			if err := processor.InitializeBean(beanDef.Name, beanDef.Instance); err != nil {
+				return err
+			}
+		}
+
+		beanDef.mutex.Lock()
+		beanDef.lazyInitialized = true
+		beanDef.mutex.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return beanDef.getInstance(), nil
+}
+
+// deferredInjection 由 Provider 和 Lazy 实现，注入时只记录如何解析目标Bean
+type deferredInjection interface {
+	bind(factory BeanFactory, name, qualifier string)
+	targetType() reflect.Type
+}
+
+var deferredInjectionType = reflect.TypeOf((*deferredInjection)(nil)).Elem()
+
+// isDeferred 检查类型是否为 Provider[T] 或 Lazy[T]
+func isDeferred(typ reflect.Type) bool {
+	return reflect.PtrTo(typ).Implements(deferredInjectionType)
+}
+
+// beanRef 记录延迟解析的目标Bean
+type beanRef struct {
+	factory   BeanFactory
+	name      string
+	qualifier string
+	typ       reflect.Type
+}
+
+// lookup 从容器中解析目标Bean，指定名称时按名称，否则按类型和限定符
+func (r *beanRef) lookup() (interface{}, error) {
+	if r == nil || r.factory == nil {
+		return nil, fmt.Errorf("deferred reference is not bound to a container")
+	}
+	if r.name != "" {
+		return r.factory.LookupBean(r.name)
+	}
+	return r.factory.LookupQualifiedBean(r.typ, r.qualifier)
+}
+
+// Provider 在每次调用时从容器中解析Bean
+//
+// 以 inject 标签注入或作为构造函数参数使用，例如 Jobs container.Provider[*Job] `inject:""`。
+// 目标为原型Bean时每次调用都返回新实例。注入 Provider 不会建立依赖顺序，也不会立即创建目标Bean。
+type Provider[T any] struct {
+	ref *beanRef
+}
+
+func (p *Provider[T]) bind(factory BeanFactory, name, qualifier string) {
+	p.ref = &beanRef{factory: factory, name: name, qualifier: qualifier, typ: TypeOf[T]()}
+}
+
+func (p *Provider[T]) targetType() reflect.Type {
+	return TypeOf[T]()
+}
+
+// Get 解析Bean，失败时返回零值
+func (p Provider[T]) Get() T {
+	bean, _ := p.Lookup()
+	return bean
+}
+
+// Lookup 解析Bean，Bean不存在、创建失败或类型不匹配时返回错误
+func (p Provider[T]) Lookup() (T, error) {
+	bean, err := p.ref.lookup()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return convertBean[T](bean, TypeOf[T]())
+}
+
+// Lazy 在首次调用时从容器中解析Bean并缓存结果
+//
+// 适用于很少使用、创建代价较高的依赖，注入 Lazy 不会建立依赖顺序，也不会立即创建目标Bean。
+// 解析失败时不缓存，下次调用会重试。
+type Lazy[T any] struct {
+	state *lazyState[T]
+}
+
+type lazyState[T any] struct {
+	ref      *beanRef
+	mutex    sync.Mutex
+	value    T
+	resolved bool
+}
+
+func (l *Lazy[T]) bind(factory BeanFactory, name, qualifier string) {
+	l.state = &lazyState[T]{ref: &beanRef{factory: factory, name: name, qualifier: qualifier, typ: TypeOf[T]()}}
+}
+
+func (l *Lazy[T]) targetType() reflect.Type {
+	return TypeOf[T]()
+}
+
+// Get 解析Bean，失败时返回零值
+func (l Lazy[T]) Get() T {
+	bean, _ := l.Lookup()
+	return bean
+}
+
+// Lookup 解析Bean，首次成功后返回缓存的结果
+func (l Lazy[T]) Lookup() (T, error) {
+	var zero T
+	if l.state == nil {
+		return zero, fmt.Errorf("lazy reference to %v is not bound to a container", TypeOf[T]())
+	}
+
+	l.state.mutex.Lock()
+	defer l.state.mutex.Unlock()
+
+	if l.state.resolved {
+		return l.state.value, nil
+	}
+
+	bean, err := l.state.ref.lookup()
+	if err != nil {
+		return zero, err
+	}
+	value, err := convertBean[T](bean, TypeOf[T]())
+	if err != nil {
+		return zero, err
+	}
+
+	l.state.value = value
+	l.state.resolved = true
+	return value, nil
+}
+
+// bindDeferred 将 Provider 或 Lazy 绑定到容器，严格模式下检查目标Bean是否可以解析
+func (c *Container) bindDeferred(target reflect.Value, name, qualifier string) error {
+	deferred := target.Addr().Interface().(deferredInjection)
+
+	deferred.bind(c, name, qualifier)
+	return c.checkResolvable(name, deferred.targetType(), qualifier)
+}
+
+// checkResolvable 检查本容器或父容器中是否存在可解析的目标Bean，不创建Bean
+func (c *Container) checkResolvable(name string, typ reflect.Type, qualifier string) error {
+	var err error
+	c.mutex.RLock()
+	if name != "" {
+		if _, exists := c.beans[c.canonicalName(name)]; !exists {
+			err = &BeanNotFoundError{Name: name}
+		}
+	} else {
+		_, err = c.findBeanNameForType(typ, qualifier)
+	}
+	_, resolvable := c.resolvable[typ]
+	parent := c.parent
+	c.mutex.RUnlock()
+
+	if err != nil && name == "" && qualifier == "" && resolvable && isBeanNotFound(err) {
+		return nil
+	}
+	if err != nil && parent != nil && isBeanNotFound(err) {
+		return parent.checkResolvable(name, typ, qualifier)
+	}
+	return err
+}
@@ -0,0 +1,165 @@
+package container
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"gospring/annotations"
+)
+
+var annotationUtils = annotations.NewAnnotationUtils()
+
+// BeanOption 注册Bean时的可选配置
+type BeanOption func(*BeanDefinition)
+
+// Primary 将Bean标记为同类型Bean中的首选实现，等价于类型上的 primary:"true" 标签
+//
+// 按类型注入时若存在多个候选，且其中恰好有一个首选Bean，则注入该Bean。
+func Primary() BeanOption {
+	return func(beanDef *BeanDefinition) {
+		beanDef.Primary = true
+	}
+}
+
+// Qualifier 为Bean添加限定符，等价于类型上的 qualifier:"name" 标签
+//
+// 注入点可以通过 inject:"" qualifier:"name" 在同类型的多个Bean中选择。
+func Qualifier(qualifiers ...string) BeanOption {
+	return func(beanDef *BeanDefinition) {
+		beanDef.Qualifiers = append(beanDef.Qualifiers, qualifiers...)
+	}
+}
+
+// Order 设置Bean在切片或映射注入中的排序值，等价于类型上的 order 标签
+//
+// 实例实现 annotations.Ordered 接口时以接口返回值为准。
+func Order(order int) BeanOption {
+	return func(beanDef *BeanDefinition) {
+		beanDef.Order = order
+	}
+}
+
+// DependsOn 声明必须先于该Bean初始化的Bean，等价于类型上的 dependsOn:"a,b" 标签
+//
+// 用于没有注入关系、但存在初始化先后要求的Bean，例如数据库迁移需先于缓存预热执行。
+// 被依赖的Bean先完成初始化，并在该Bean之后销毁。
+func DependsOn(names ...string) BeanOption {
+	return func(beanDef *BeanDefinition) {
+		beanDef.DependsOn = append(beanDef.DependsOn, names...)
+	}
+}
+
+// Profile 声明Bean只在配置文件表达式成立时注册，等价于类型上的 profile:"dev,!prod" 标签
+//
+// 与条件不同，配置文件在注册时立即判断，因此不同配置文件下可以注册同名的Bean。
+func Profile(profiles ...string) BeanOption {
+	return func(beanDef *BeanDefinition) {
+		beanDef.Profiles = append(beanDef.Profiles, profiles...)
+	}
+}
+
+// ConfigProperties 声明Bean为配置属性对象，等价于类型上的 config:"prefix" 标签
+//
+// 装配之前，运行环境中 prefix 下的属性绑定到注册的实例上，见 environment.Bind。
+// 只对注册的实例生效，构造函数注册的Bean在构造函数中自行读取配置。
+func ConfigProperties(prefix string) BeanOption {
+	return func(beanDef *BeanDefinition) {
+		beanDef.ConfigPrefix = prefix
+	}
+}
+
+// LazyInit 将单例Bean标记为延迟创建，等价于类型上的 lazy:"true" 标签
+//
+// 延迟Bean不参与启动时的装配，首次获取时才创建、注入依赖并执行初始化回调。
+// 若被其他非延迟Bean直接注入，则在注入时创建。
+func LazyInit() BeanOption {
+	return func(beanDef *BeanDefinition) {
+		beanDef.Lazy = true
+	}
+}
+
+// DestroyTimeout 设置停止上下文时销毁该Bean的时限，等价于类型上的 destroyTimeout:"5s" 标签
+//
+// 覆盖应用上下文通过 SetDestroyTimeout 设置的默认时限。
+func DestroyTimeout(timeout time.Duration) BeanOption {
+	return func(beanDef *BeanDefinition) {
+		beanDef.DestroyTimeout = timeout
+	}
+}
+
+// applyOptions 读取类型上的 primary、qualifier、order、lazy、dependsOn、profile、config 和 destroyTimeout 标签，再应用注册时传入的选项
+//
+// order 或 destroyTimeout 标签的值无效时返回错误，避免拼写错误静默改变排序或销毁时限。
+func (beanDef *BeanDefinition) applyOptions(opts []BeanOption) error {
+	beanDef.Primary = annotationUtils.IsPrimary(beanDef.instanceType)
+	beanDef.Lazy = annotationUtils.IsLazy(beanDef.instanceType)
+	beanDef.Qualifiers = annotationUtils.GetQualifiers(beanDef.instanceType)
+	beanDef.DependsOn = annotationUtils.GetDependsOn(beanDef.instanceType)
+	beanDef.Profiles = annotationUtils.GetProfiles(beanDef.instanceType)
+	beanDef.ConfigPrefix = annotationUtils.GetConfigPrefix(beanDef.instanceType)
+	beanDef.Order = annotations.LowestPrecedence
+	order, ok, err := annotationUtils.GetOrder(beanDef.instanceType)
+	if err != nil {
+		return err
+	}
+	if ok {
+		beanDef.Order = order
+	}
+	if beanDef.DestroyTimeout, _, err = annotationUtils.GetDestroyTimeout(beanDef.instanceType); err != nil {
+		return err
+	}
+
+	for _, opt := range opts {
+		opt(beanDef)
+	}
+	return nil
+}
+
+// order 获取Bean的排序值，注册的实例实现 annotations.Ordered 时以接口返回值为准
+//
+// 构造函数注册的Bean在创建前没有实例，只使用 order 标签或 Order 选项。
+func (beanDef *BeanDefinition) order() int {
+	if beanDef.factory.IsValid() {
+		return beanDef.Order
+	}
+	if ordered, ok := beanDef.getInstance().(annotations.Ordered); ok {
+		return ordered.Order()
+	}
+	return beanDef.Order
+}
+
+// sortByOrder 按排序值对Bean名称稳定排序，排序值相同时保持原有顺序，调用方需持有锁
+func (c *Container) sortByOrder(names []string) {
+	sort.SliceStable(names, func(i, j int) bool {
+		return c.beans[names[i]].order() < c.beans[names[j]].order()
+	})
+}
+
+// HasQualifier 检查Bean是否匹配限定符，Bean名称本身也视为限定符
+func (beanDef *BeanDefinition) HasQualifier(qualifier string) bool {
+	if beanDef.Name == qualifier {
+		return true
+	}
+	for _, q := range beanDef.Qualifiers {
+		if q == qualifier {
+			return true
+		}
+	}
+	return false
+}
+
+// selectCandidate 在多个候选Bean中选出唯一的首选Bean，调用方需持有锁
+func (c *Container) selectCandidate(typ reflect.Type, candidates []string) (string, error) {
+	var primaries []string
+	for _, name := range candidates {
+		if c.beans[name].Primary {
+			primaries = append(primaries, name)
+		}
+	}
+
+	if len(primaries) == 1 {
+		return primaries[0], nil
+	}
+	return "", &NoUniqueBeanError{Type: typ, Candidates: candidates}
+}
@@ -0,0 +1,135 @@
+package container
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"gospring/logging"
+)
+
+// OverridePolicy 注册同名Bean时的处理策略
+type OverridePolicy int
+
+const (
+	// OverrideForbidden 禁止覆盖，重复注册返回 *DuplicateBeanError（默认）
+	OverrideForbidden OverridePolicy = iota
+	// OverrideAllowed 允许覆盖，新的定义替换原有定义
+	OverrideAllowed
+	// OverrideWarn 允许覆盖，并以警告级别记录 BeanDefinitionOverridden 事件
+	OverrideWarn
+)
+
+// SetOverridePolicy 设置注册同名Bean时的处理策略
+//
+// 覆盖应在装配之前完成，已被注入到其他Bean中的旧实例不会被替换。
+func (c *Container) SetOverridePolicy(policy OverridePolicy) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.overridePolicy = policy
+}
+
+// GetOverridePolicy 获取注册同名Bean时的处理策略
+func (c *Container) GetOverridePolicy() OverridePolicy {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.overridePolicy
+}
+
+// RegisterAlias 为Bean注册别名，通过别名可以像Bean名称一样获取和注入Bean
+//
+// 目标Bean可以稍后注册；别名不能与已有的Bean名称相同。
+// 已存在的别名只有在覆盖策略允许时才能指向另一个Bean。
+func (c *Container) RegisterAlias(alias, name string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if alias == "" || name == "" {
+		return fmt.Errorf("alias and bean name must not be empty")
+	}
+	if existing, exists := c.beans[alias]; exists {
+		return &DuplicateBeanError{Name: alias, ExistingType: existing.Type}
+	}
+	if c.canonicalName(name) == alias {
+		return fmt.Errorf("cannot register alias '%s' for '%s': it would create a cycle", alias, name)
+	}
+	if target, exists := c.aliases[alias]; exists && target != name && c.overridePolicy == OverrideForbidden {
+		return fmt.Errorf("alias '%s' is already registered for bean '%s'", alias, target)
+	}
+
+	c.aliases[alias] = name
+	return nil
+}
+
+// GetAliases 按字母顺序返回指向该Bean的所有别名
+func (c *Container) GetAliases(name string) []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	name = c.canonicalName(name)
+	var aliases []string
+	for alias := range c.aliases {
+		if alias != name && c.canonicalName(alias) == name {
+			aliases = append(aliases, alias)
+		}
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// canonicalName 将别名解析为Bean名称，调用方需持有锁
+func (c *Container) canonicalName(name string) string {
+	for {
+		target, isAlias := c.aliases[name]
+		if !isAlias {
+			return name
+		}
+		name = target
+	}
+}
+
+// addDefinition 添加Bean定义，存在同名定义时按覆盖策略处理，调用方需持有写锁
+//
+// 覆盖的定义保留原有的注册顺序，并通过 BeanDefinition.Overrides 记录被覆盖的定义。
+func (c *Container) addDefinition(beanDef *BeanDefinition) error {
+	name := beanDef.Name
+	existing, exists := c.beans[name]
+	_, isAlias := c.aliases[name]
+
+	if exists || isAlias {
+		if c.overridePolicy == OverrideForbidden {
+			existingType := beanDef.Type
+			if exists {
+				existingType = existing.Type
+			} else if target, found := c.beans[c.canonicalName(name)]; found {
+				existingType = target.Type
+			}
+			return &DuplicateBeanError{Name: name, ExistingType: existingType}
+		}
+		// 新的Bean名称取代同名的别名
+		delete(c.aliases, name)
+	}
+
+	c.beans[name] = beanDef
+	if !exists {
+		c.order = append(c.order, name)
+	} else {
+		beanDef.Overrides = existing
+		// 清除新定义不再满足的类型映射
+		for typ, mapped := range c.typeMapping {
+			if mapped == name && !beanDef.isAssignableTo(typ) {
+				delete(c.typeMapping, typ)
+				delete(c.autoBound, typ)
+			}
+		}
+		c.logger.LogEvent(&logging.BeanDefinitionOverridden{
+			Timestamp:     time.Now(),
+			ComponentID:   name,
+			PreviousType:  existing.Type.String(),
+			ComponentType: beanDef.Type.String(),
+			Warning:       c.overridePolicy == OverrideWarn,
+		})
+	}
+	c.registerInterfaces(beanDef.instanceType, name)
+	return nil
+}
@@ -0,0 +1,127 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"gospring/logging"
+)
+
+// LifecycleProcessor 执行Bean的生命周期回调，ApplicationContext 通过它接入生命周期管理
+//
+// 未设置时容器只负责创建和注入，不执行任何生命周期回调。
+type LifecycleProcessor interface {
+	// InitializeBean 在延迟单例首次创建并完成注入后调用
+	InitializeBean(name string, bean interface{}) error
+	// InitializeInstance 在原型或自定义作用域Bean的每个实例创建并完成注入后调用
+	InitializeInstance(name string, bean interface{}) error
+	// DestroyInstance 在原型实例被释放或自定义作用域结束时调用
+	DestroyInstance(name string, bean interface{}) error
+}
+
+// SetLifecycleProcessor 设置生命周期回调的处理器
+func (c *Container) SetLifecycleProcessor(processor LifecycleProcessor) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.lifecycleProcessor = processor
+}
+
+// getLifecycleProcessor 获取生命周期回调的处理器
+func (c *Container) getLifecycleProcessor() LifecycleProcessor {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.lifecycleProcessor
+}
+
+// RegisterPrototypeProvider 通过构造函数注册原型Bean，每次获取都调用构造函数创建新实例
+func (c *Container) RegisterPrototypeProvider(name string, fn interface{}, opts ...BeanOption) error {
+	return c.registerProvider(name, fn, ScopePrototype, opts)
+}
+
+// DestroyBean 对不再使用的原型实例执行销毁回调
+//
+// 单例由容器管理，随上下文停止一起销毁，不能通过该方法销毁。
+func (c *Container) DestroyBean(name string, instance interface{}) error {
+	c.mutex.RLock()
+	beanDef, exists := c.beans[c.canonicalName(name)]
+	c.mutex.RUnlock()
+
+	if !exists {
+		return &BeanNotFoundError{Name: name}
+	}
+	if beanDef.Singleton {
+		return fmt.Errorf("bean '%s' is a singleton and is destroyed with its container", name)
+	}
+	return c.destroyInstance(beanDef.Name, instance)
+}
+
+// createNewInstance 创建新的实例（用于原型模式）
+//
+// 通过构造函数注册的原型每次调用构造函数；以实例注册的原型通过 copyTemplate 从注册时的实例复制，
+// 保留其中已配置的字段。新实例重新执行依赖注入，并交给生命周期处理器初始化。
+func (c *Container) createNewInstance(beanDef *BeanDefinition, cr creation) (interface{}, error) {
+	var instance interface{}
+	if beanDef.factory.IsValid() {
+		var err error
+		if instance, err = c.invokeFactory(beanDef, cr); err != nil {
+			return nil, err
+		}
+	} else {
+		start := time.Now()
+		var err error
+		if instance, err = copyTemplate(beanDef); err != nil {
+			return nil, &BeanCreationError{Name: beanDef.Name, Type: beanDef.Type, Cause: err}
+		}
+
+		// 执行依赖注入
+		cr = cr.enter(beanDef.Name)
+		if err := c.injectDependencies(beanDef.Name, instance, cr); err != nil {
+			return nil, &BeanCreationError{Name: beanDef.Name, Type: beanDef.Type, Cause: err}
+		}
+
+		// 记录组件创建事件
+		c.logger.LogEvent(&logging.ComponentCreated{
+			Timestamp:     time.Now(),
+			ComponentID:   beanDef.Name,
+			ComponentType: beanDef.Type.String(),
+			CreationTime:  time.Since(start),
+		})
+	}
+
+	if processor := c.getLifecycleProcessor(); processor != nil {
+		if err := processor.InitializeInstance(beanDef.Name, instance); err != nil {
+			return nil, &BeanCreationError{Name: beanDef.Name, Type: beanDef.Type, Cause: err}
+		}
+	}
+
+	return instance, nil
+}
+
+// copyTemplate 复制原型的注册实例，返回指向副本的指针
+//
+// 注册实例的指针类型带有 Clone() *T 方法时调用该方法创建副本；否则浅拷贝结构体，
+// 指针、切片和映射字段与注册实例共享，注册实例引用的协作对象、缓存和连接不会被复制。
+func copyTemplate(beanDef *BeanDefinition) (interface{}, error) {
+	template := beanDef.Value
+	if template.Kind() == reflect.Ptr {
+		if template.IsNil() {
+			return reflect.New(beanDef.Type).Interface(), nil
+		}
+		if clone := template.MethodByName("Clone"); clone.IsValid() {
+			cloneType := clone.Type()
+			if cloneType.NumIn() == 0 && cloneType.NumOut() == 1 && cloneType.Out(0) == template.Type() {
+				copied := clone.Call(nil)[0]
+				if copied.IsNil() {
+					return nil, fmt.Errorf("prototype '%s': Clone returned nil", beanDef.Name)
+				}
+				return copied.Interface(), nil
+			}
+		}
+		template = template.Elem()
+	}
+
+	newVal := reflect.New(beanDef.Type)
+	newVal.Elem().Set(template)
+	return newVal.Interface(), nil
+}
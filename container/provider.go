@@ -0,0 +1,206 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"gospring/logging"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// RegisterProvider 通过构造函数注册单例Bean
+//
+// fn 可以是任意函数，例如 func(repo UserRepository, log logging.Logger) (*UserService, error)。
+// 函数的参数在创建时按类型从容器中解析，第一个返回值作为Bean实例，
+// 可选的第二个返回值必须为error，非nil时Bean创建失败。
+func (c *Container) RegisterProvider(name string, fn interface{}, opts ...BeanOption) error {
+	return c.registerProvider(name, fn, ScopeSingleton, opts)
+}
+
+// registerProvider 内部注册构造函数方法
+func (c *Container) registerProvider(name string, fn interface{}, scope string, opts []BeanOption) error {
+	fnVal := reflect.ValueOf(fn)
+	if err := validateProvider(fnVal); err != nil {
+		return fmt.Errorf("invalid provider for bean '%s': %v", name, err)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	originalType := fnVal.Type().Out(0)
+	typ := originalType
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	beanDef := &BeanDefinition{
+		Name:         name,
+		Type:         typ,
+		Singleton:    scope == ScopeSingleton,
+		Scope:        scope,
+		Factory:      fn,
+		instanceType: originalType,
+		factory:      fnVal,
+	}
+	if err := beanDef.applyOptions(opts); err != nil {
+		return fmt.Errorf("invalid bean '%s': %w", name, err)
+	}
+
+	if !c.acceptsProfiles(beanDef) {
+		return nil
+	}
+	if err := c.addDefinition(beanDef); err != nil {
+		return err
+	}
+
+	c.logger.LogEvent(&logging.ComponentRegistered{
+		Timestamp:     time.Now(),
+		ComponentID:   name,
+		ComponentType: typ.String(),
+		Scope:         scope,
+	})
+
+	return nil
+}
+
+// validateProvider 检查构造函数的签名
+func validateProvider(fnVal reflect.Value) error {
+	if !fnVal.IsValid() || fnVal.Kind() != reflect.Func {
+		return fmt.Errorf("provider must be a function")
+	}
+	if fnVal.IsNil() {
+		return fmt.Errorf("provider function is nil")
+	}
+
+	fnType := fnVal.Type()
+	if fnType.IsVariadic() {
+		return fmt.Errorf("variadic provider %v is not supported", fnType)
+	}
+
+	switch fnType.NumOut() {
+	case 1:
+	case 2:
+		if fnType.Out(1) != errorType {
+			return fmt.Errorf("second return value of provider %v must be error", fnType)
+		}
+	default:
+		return fmt.Errorf("provider %v must return (T) or (T, error)", fnType)
+	}
+
+	if fnType.Out(0) == errorType {
+		return fmt.Errorf("provider %v must return a bean as its first value", fnType)
+	}
+
+	return nil
+}
+
+// getOrCreateSingleton 获取构造函数注册的单例，首次访问时创建
+//
+// 构造函数在 createSingleton 中执行，不持有Bean定义的锁，因此不同协程同时创建互相依赖的单例时不会死锁。
+func (c *Container) getOrCreateSingleton(beanDef *BeanDefinition, cr creation) (interface{}, error) {
+	err := c.createSingleton(beanDef, cr, beanDef.hasInstance, func(cr creation) error {
+		instance, err := c.invokeFactory(beanDef, cr)
+		if err != nil {
+			return err
+		}
+		beanDef.setInstance(instance)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return beanDef.getInstance(), nil
+}
+
+// getInstance 获取单例实例，重新绑定的配置对象可能在运行期间被替换
+func (beanDef *BeanDefinition) getInstance() interface{} {
+	beanDef.mutex.RLock()
+	defer beanDef.mutex.RUnlock()
+	return beanDef.Instance
+}
+
+// hasInstance 检查单例实例是否已经创建
+func (beanDef *BeanDefinition) hasInstance() bool {
+	beanDef.mutex.RLock()
+	defer beanDef.mutex.RUnlock()
+	return beanDef.Instance != nil
+}
+
+// setInstance 设置创建好的单例实例，或替换重新绑定的配置对象
+func (beanDef *BeanDefinition) setInstance(instance interface{}) {
+	beanDef.mutex.Lock()
+	defer beanDef.mutex.Unlock()
+	beanDef.Instance = instance
+	beanDef.Value = reflect.ValueOf(instance)
+}
+
+// invokeFactory 解析构造函数参数并调用构造函数创建实例
+func (c *Container) invokeFactory(beanDef *BeanDefinition, cr creation) (interface{}, error) {
+	start := time.Now()
+	cr = cr.enter(beanDef.Name)
+
+	fnType := beanDef.factory.Type()
+	args := make([]reflect.Value, fnType.NumIn())
+	for i := 0; i < fnType.NumIn(); i++ {
+		paramType := fnType.In(i)
+
+		var arg reflect.Value
+		var err error
+		if isDeferred(paramType) {
+			// Provider 和 Lazy 参数只绑定到容器，调用时才解析目标Bean
+			arg = reflect.New(paramType).Elem()
+			err = c.bindDeferred(arg, "", "")
+		} else {
+			var dependency interface{}
+			dependency, err = c.getBeanByType(paramType, "", cr)
+			if err == nil && (dependency == nil || !reflect.TypeOf(dependency).AssignableTo(paramType)) {
+				err = fmt.Errorf("bean of type %T is not assignable to %v", dependency, paramType)
+			}
+			arg = reflect.ValueOf(dependency)
+		}
+		if err != nil {
+			return nil, &BeanCreationError{
+				Name: beanDef.Name,
+				Type: beanDef.Type,
+				Cause: &UnsatisfiedDependencyError{
+					BeanName:       beanDef.Name,
+					BeanType:       beanDef.Type,
+					FieldName:      fmt.Sprintf("arg%d", i),
+					DependencyType: paramType,
+					Cause:          err,
+				},
+			}
+		}
+		args[i] = arg
+	}
+
+	results := beanDef.factory.Call(args)
+	if len(results) == 2 && !results[1].IsNil() {
+		return nil, &BeanCreationError{Name: beanDef.Name, Type: beanDef.Type, Cause: results[1].Interface().(error)}
+	}
+
+	result := results[0]
+	if (result.Kind() == reflect.Ptr || result.Kind() == reflect.Interface) && result.IsNil() {
+		return nil, &BeanCreationError{Name: beanDef.Name, Type: beanDef.Type, Cause: fmt.Errorf("provider returned nil")}
+	}
+	instance := result.Interface()
+
+	// 对构造函数返回的结构体指针执行字段注入
+	if result.Kind() == reflect.Ptr && result.Elem().Kind() == reflect.Struct {
+		if err := c.injectDependencies(beanDef.Name, instance, cr); err != nil {
+			return nil, &BeanCreationError{Name: beanDef.Name, Type: beanDef.Type, Cause: err}
+		}
+	}
+
+	c.logger.LogEvent(&logging.ComponentCreated{
+		Timestamp:     time.Now(),
+		ComponentID:   beanDef.Name,
+		ComponentType: beanDef.Type.String(),
+		CreationTime:  time.Since(start),
+	})
+
+	return instance, nil
+}
@@ -0,0 +1,92 @@
+package container
+
+import (
+	"context"
+	"reflect"
+)
+
+// requestScopeKey 请求作用域在 context.Context 中的键
+type requestScopeKey struct{}
+
+// OpenRequestScope 在上下文中开启新的请求作用域
+//
+// 请求作用域Bean在返回的上下文中首次获取时创建并保存在该作用域内，
+// 调用返回的 close 函数结束作用域，按创建顺序的逆序执行销毁回调。
+func OpenRequestScope(parent context.Context) (ctx context.Context, close func()) {
+	scope := NewSimpleScope()
+	return context.WithValue(parent, requestScopeKey{}, scope), scope.Close
+}
+
+// RequestScopeFrom 获取上下文中开启的请求作用域
+func RequestScopeFrom(ctx context.Context) (*SimpleScope, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	scope, ok := ctx.Value(requestScopeKey{}).(*SimpleScope)
+	return scope, ok
+}
+
+// ContextBeanFactory 可在指定上下文中解析Bean，请求作用域Bean从上下文的请求作用域中获取
+type ContextBeanFactory interface {
+	LookupBeanInContext(ctx context.Context, name string) (interface{}, error)
+	LookupQualifiedBeanInContext(ctx context.Context, typ reflect.Type, qualifier string) (interface{}, error)
+}
+
+// LookupBeanInContext 在指定上下文中按名称获取Bean
+func (c *Container) LookupBeanInContext(ctx context.Context, name string) (interface{}, error) {
+	return c.getBean(name, creation{ctx: ctx})
+}
+
+// LookupBeanByTypeInContext 在指定上下文中按类型获取Bean
+func (c *Container) LookupBeanByTypeInContext(ctx context.Context, typ reflect.Type) (interface{}, error) {
+	return c.getBeanByType(typ, "", creation{ctx: ctx})
+}
+
+// LookupQualifiedBeanInContext 在指定上下文中按类型和限定符获取Bean
+func (c *Container) LookupQualifiedBeanInContext(ctx context.Context, typ reflect.Type, qualifier string) (interface{}, error) {
+	return c.getBeanByType(typ, qualifier, creation{ctx: ctx})
+}
+
+// ResolveInContext 在指定上下文中按类型参数解析Bean，用于在请求处理中获取请求作用域Bean
+func ResolveInContext[T any](ctx context.Context, f ContextBeanFactory) (T, error) {
+	typ := TypeOf[T]()
+	bean, err := f.LookupQualifiedBeanInContext(ctx, typ, "")
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return convertBean[T](bean, typ)
+}
+
+// lookupInContext 在指定上下文中解析目标Bean，工厂不支持上下文时退回普通解析
+func (r *beanRef) lookupInContext(ctx context.Context) (interface{}, error) {
+	if r == nil {
+		return r.lookup()
+	}
+	contextual, ok := r.factory.(ContextBeanFactory)
+	if !ok {
+		return r.lookup()
+	}
+	if r.name != "" {
+		return contextual.LookupBeanInContext(ctx, r.name)
+	}
+	return contextual.LookupQualifiedBeanInContext(ctx, r.typ, r.qualifier)
+}
+
+// GetContext 在指定上下文中解析Bean，失败时返回零值
+//
+// 单例依赖请求作用域Bean时注入 Provider[T]，在处理请求时以请求的上下文调用。
+func (p Provider[T]) GetContext(ctx context.Context) T {
+	bean, _ := p.LookupContext(ctx)
+	return bean
+}
+
+// LookupContext 在指定上下文中解析Bean，Bean不存在、创建失败或类型不匹配时返回错误
+func (p Provider[T]) LookupContext(ctx context.Context) (T, error) {
+	bean, err := p.ref.lookupInContext(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return convertBean[T](bean, TypeOf[T]())
+}
@@ -0,0 +1,252 @@
+package container
+
+import (
+	"fmt"
+	"sync"
+)
+
+// 内置作用域名称
+const (
+	ScopeSingleton = "singleton"
+	ScopePrototype = "prototype"
+	ScopeRequest   = "request" // 绑定到 context.Context 的请求作用域，见 OpenRequestScope
+	ScopeRefresh   = "refresh" // 配置变化后重新创建的刷新作用域，见 RefreshScope
+)
+
+// ObjectFactory 创建作用域内的新实例，实例已完成依赖注入和初始化回调
+type ObjectFactory func() (interface{}, error)
+
+// Scope 自定义作用域，决定作用域Bean的实例何时创建、复用和销毁
+//
+// 通过 RegisterScope 注册后，以 scope:"<name>" 标签或 RegisterScoped 声明的Bean
+// 每次获取都交给对应的作用域处理。
+type Scope interface {
+	// Get 返回作用域中名为 name 的实例，不存在时调用 factory 创建并保存
+	Get(name string, factory ObjectFactory) (interface{}, error)
+	// Remove 从作用域中移除名为 name 的实例并返回它，不存在时返回nil
+	Remove(name string) interface{}
+	// RegisterDestructionCallback 注册实例销毁时执行的回调，由作用域在实例结束时调用
+	RegisterDestructionCallback(name string, callback func())
+}
+
+// RegisterScope 以名称注册自定义作用域，内置的 singleton、prototype、request 和 refresh 不能被替换
+func (c *Container) RegisterScope(name string, scope Scope) error {
+	if name == ScopeSingleton || name == ScopePrototype || name == ScopeRequest || name == ScopeRefresh {
+		return fmt.Errorf("cannot replace built-in scope '%s'", name)
+	}
+	if scope == nil {
+		return fmt.Errorf("scope '%s' is nil", name)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.scopes[name] = scope
+	return nil
+}
+
+// GetRegisteredScope 获取已注册的自定义作用域，不存在时返回nil
+func (c *Container) GetRegisteredScope(name string) Scope {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.scopes[name]
+}
+
+// RegisterScoped 以指定作用域注册Bean，作用域可以是 singleton、prototype 或已注册的自定义作用域
+//
+// 自定义作用域在获取Bean时才检查，因此可以先注册Bean、后注册作用域。
+func (c *Container) RegisterScoped(name string, instance interface{}, scope string, opts ...BeanOption) error {
+	return c.registerBean(name, instance, scopeOrDefault(scope), opts)
+}
+
+// RegisterScopedProvider 通过构造函数以指定作用域注册Bean
+func (c *Container) RegisterScopedProvider(name string, fn interface{}, scope string, opts ...BeanOption) error {
+	return c.registerProvider(name, fn, scopeOrDefault(scope), opts)
+}
+
+// DestroyScopedBean 从自定义作用域中移除Bean的当前实例并执行销毁回调
+func (c *Container) DestroyScopedBean(name string) error {
+	c.mutex.RLock()
+	beanDef, exists := c.beans[c.canonicalName(name)]
+	c.mutex.RUnlock()
+
+	if !exists {
+		return &BeanNotFoundError{Name: name}
+	}
+
+	scope, err := c.scopeOf(beanDef, creation{})
+	if err != nil {
+		return err
+	}
+	if instance := scope.Remove(beanDef.Name); instance != nil {
+		return c.destroyInstance(beanDef.Name, instance)
+	}
+	return nil
+}
+
+// RefreshScope 结束刷新作用域中的所有实例并执行销毁回调，刷新作用域Bean在下次获取时重新创建
+//
+// 刷新作用域Bean的每次创建都会重新解析 value 标签，通常在配置变化后调用。
+// 需要看到新实例的单例应注入 Provider[T] 并在使用时获取。
+func (c *Container) RefreshScope() {
+	c.refreshScope.Close()
+}
+
+// scopeOrDefault 未指定作用域时使用单例
+func scopeOrDefault(scope string) string {
+	if scope == "" {
+		return ScopeSingleton
+	}
+	return scope
+}
+
+// scopeOf 获取Bean所属的自定义作用域，请求作用域从解析过程的上下文中获取
+func (c *Container) scopeOf(beanDef *BeanDefinition, cr creation) (Scope, error) {
+	if beanDef.Scope == ScopeRequest {
+		if scope, ok := RequestScopeFrom(cr.ctx); ok {
+			return scope, nil
+		}
+		return nil, &ScopeNotActiveError{Scope: beanDef.Scope, BeanName: beanDef.Name}
+	}
+	if beanDef.Scope == ScopeRefresh {
+		return c.refreshScope, nil
+	}
+
+	c.mutex.RLock()
+	scope, exists := c.scopes[beanDef.Scope]
+	c.mutex.RUnlock()
+
+	if !exists {
+		return nil, &ScopeNotActiveError{Scope: beanDef.Scope, BeanName: beanDef.Name}
+	}
+	return scope, nil
+}
+
+// getScopedBean 从自定义作用域获取Bean，作用域中没有实例时创建并注册销毁回调
+func (c *Container) getScopedBean(beanDef *BeanDefinition, cr creation) (interface{}, error) {
+	scope, err := c.scopeOf(beanDef, cr)
+	if err != nil {
+		return nil, &BeanCreationError{Name: beanDef.Name, Type: beanDef.Type, Cause: err}
+	}
+
+	return scope.Get(beanDef.Name, func() (interface{}, error) {
+		instance, err := c.createNewInstance(beanDef, cr)
+		if err != nil {
+			return nil, err
+		}
+		scope.RegisterDestructionCallback(beanDef.Name, func() {
+			c.destroyInstance(beanDef.Name, instance)
+		})
+		return instance, nil
+	})
+}
+
+// destroyInstance 对单个实例执行销毁回调
+func (c *Container) destroyInstance(name string, instance interface{}) error {
+	if processor := c.getLifecycleProcessor(); processor != nil {
+		return processor.DestroyInstance(name, instance)
+	}
+	return nil
+}
+
+// SimpleScope 基于映射的并发安全作用域，适用于租户、任务、测试等由调用方控制生命周期的作用域
+//
+// 调用 Close 结束作用域：执行所有销毁回调并清空实例，之后可以继续使用。
+type SimpleScope struct {
+	mutex     sync.Mutex
+	instances map[string]interface{}
+	callbacks map[string]func()
+	order     []string               // 实例的创建顺序，Close 时逆序销毁
+	creating  map[string]*sync.Mutex // 保证同名实例只创建一次，创建期间不持有作用域的锁
+}
+
+// NewSimpleScope 创建空的作用域
+func NewSimpleScope() *SimpleScope {
+	return &SimpleScope{
+		instances: make(map[string]interface{}),
+		callbacks: make(map[string]func()),
+		creating:  make(map[string]*sync.Mutex),
+	}
+}
+
+// Get 返回作用域中的实例，不存在时创建
+//
+// 创建期间不持有作用域的锁，因此作用域Bean可以依赖同一作用域中的其他Bean。
+func (s *SimpleScope) Get(name string, factory ObjectFactory) (interface{}, error) {
+	s.mutex.Lock()
+	if instance, exists := s.instances[name]; exists {
+		s.mutex.Unlock()
+		return instance, nil
+	}
+	creating, exists := s.creating[name]
+	if !exists {
+		creating = &sync.Mutex{}
+		s.creating[name] = creating
+	}
+	s.mutex.Unlock()
+
+	creating.Lock()
+	defer creating.Unlock()
+
+	s.mutex.Lock()
+	instance, exists := s.instances[name]
+	s.mutex.Unlock()
+	if exists {
+		return instance, nil
+	}
+
+	instance, err := factory()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	s.instances[name] = instance
+	s.order = append(s.order, name)
+	s.mutex.Unlock()
+	return instance, nil
+}
+
+// Remove 移除实例并返回它，同时丢弃其销毁回调，由调用方负责销毁
+func (s *SimpleScope) Remove(name string) interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	instance, exists := s.instances[name]
+	if !exists {
+		return nil
+	}
+
+	delete(s.instances, name)
+	delete(s.callbacks, name)
+	for i, n := range s.order {
+		if n == name {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+	return instance
+}
+
+// RegisterDestructionCallback 注册实例的销毁回调
+func (s *SimpleScope) RegisterDestructionCallback(name string, callback func()) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.callbacks[name] = callback
+}
+
+// Close 按创建顺序的逆序执行所有销毁回调，并清空作用域
+func (s *SimpleScope) Close() {
+	s.mutex.Lock()
+	order := s.order
+	callbacks := s.callbacks
+	s.instances = make(map[string]interface{})
+	s.callbacks = make(map[string]func())
+	s.order = nil
+	s.mutex.Unlock()
+
+	for i := len(order) - 1; i >= 0; i-- {
+		if callback, exists := callbacks[order[i]]; exists {
+			callback()
+		}
+	}
+}
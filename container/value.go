@@ -0,0 +1,76 @@
+package container
+
+import (
+	"errors"
+	"reflect"
+	"time"
+
+	"gospring/environment"
+	"gospring/logging"
+)
+
+// injectValue 解析 value 标签中的占位符，转换为字段类型后赋值
+//
+// 例如 Port int `value:"${server.port:8080}"`，属性从容器的运行环境中获取。
+// 转换失败时错误中的敏感属性值被遮盖。
+func (c *Container) injectValue(field reflect.Value, expression string) error {
+	env := c.GetEnvironment()
+	value, err := environment.ResolvePlaceholders(env, expression)
+	if err != nil {
+		return err
+	}
+
+	converted, err := environment.Convert(value, field.Type())
+	if err != nil {
+		key := environment.PlaceholderKey(expression)
+		if key == "" {
+			key = expression
+		}
+		shown := environment.MaskValue(env, key, value)
+		if environment.IsSecretValue(expression) {
+			shown = environment.MaskedValue
+		}
+		return &environment.ConversionError{Key: key, Value: shown, TargetType: field.Type(), Cause: err}
+	}
+	field.Set(converted)
+	return nil
+}
+
+// valueInjectionFailed 记录依赖注入失败事件，并返回对应的 *ValueInjectionError
+func (c *Container) valueInjectionFailed(beanName string, typ reflect.Type, field reflect.StructField, expression string, err error) error {
+	c.logger.LogEvent(&logging.DependencyInjectionFailed{
+		Timestamp:      time.Now(),
+		TargetType:     typ.String(),
+		DependencyType: field.Type.String(),
+		FieldName:      field.Name,
+		Error:          err,
+	})
+
+	key := environment.PlaceholderKey(expression)
+	var notFound *environment.PropertyNotFoundError
+	var conversion *environment.ConversionError
+	if errors.As(err, &notFound) {
+		key = notFound.Key
+	} else if errors.As(err, &conversion) {
+		key = conversion.Key
+	}
+
+	return &ValueInjectionError{
+		BeanName:   beanName,
+		BeanType:   typ,
+		FieldName:  field.Name,
+		Expression: expression,
+		Key:        key,
+		Cause:      err,
+	}
+}
+
+// hasValueInjectionError 检查注入失败中是否包含属性值注入失败，这类失败在非严格模式下同样导致装配失败
+func hasValueInjectionError(failures []error) bool {
+	for _, err := range failures {
+		if errors.Is(err, ErrValueInjection) {
+			return true
+		}
+	}
+	return false
+}
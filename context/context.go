@@ -1,16 +1,27 @@
 package context
 
 import (
+	stdcontext "context"
+	"errors"
 	"fmt"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"time"
 	"gospring/container"
+	"gospring/environment"
 	"gospring/scanner"
 	"gospring/lifecycle"
 	"gospring/annotations"
 	"gospring/logging"
 )
 
+// 应用上下文状态错误
+var (
+	ErrAlreadyStarted = errors.New("application context is already started")
+	ErrNotStarted     = errors.New("application context is not started")
+)
+
 // ApplicationContext 应用上下文
 type ApplicationContext struct {
 	container         *container.Container
@@ -18,7 +29,18 @@ type ApplicationContext struct {
 	lifecycleManager  *lifecycle.LifecycleManager
 	annotationUtils   *annotations.AnnotationUtils
 	logger            logging.Logger
-	started           bool
+	started           atomic.Bool // 信号触发的停止在其他协程中修改
+	initialized       []string               // 已完成初始化的Bean（含启动前获取的延迟Bean），按初始化顺序排列
+	initializedBeans  map[string]interface{} // 已完成初始化的Bean实例
+	wiring            bool                   // 是否处于启动时的装配阶段
+	mutex             sync.Mutex             // 保护初始化记录，延迟Bean可能在任意协程中初始化
+	parent            *ApplicationContext    // 父上下文，共享其中的基础设施Bean
+	environment       *environment.StandardEnvironment
+	stopWatching      chan struct{} // 关闭时停止 WatchConfig 启动的轮询
+	stopHook          chan struct{} // 关闭时撤销 RegisterShutdownHook 注册的钩子
+	stopMutex         sync.Mutex    // 保证信号触发的停止与其他停止不会同时进行
+	shutdownTimeout   time.Duration // 销毁所有Bean的总时限，为0时不限制
+	destroyTimeout    time.Duration // 销毁单个Bean的默认时限，为0时不限制
 }
 
 // NewApplicationContext 创建新的应用上下文
@@ -29,41 +51,119 @@ func NewApplicationContext() *ApplicationContext {
 // NewApplicationContextWithLogger 创建带有指定日志器的应用上下文
 func NewApplicationContextWithLogger(logger logging.Logger) *ApplicationContext {
 	c := container.NewContainerWithLogger(logger)
-	return &ApplicationContext{
+	ctx := &ApplicationContext{
 		container:        c,
 		scanner:          scanner.NewComponentScanner(c),
 		lifecycleManager: lifecycle.NewLifecycleManager(),
 		annotationUtils:  annotations.NewAnnotationUtils(),
+		environment:      environment.NewStandardEnvironment(),
 		logger:           logger,
-		started:          false,
 	}
+	c.SetLifecycleProcessor(contextLifecycle{ctx: ctx})
+	c.SetEnvironment(ctx.environment)
+	return ctx
+}
+
+// NewChildApplicationContext 创建以 parent 为父上下文的应用上下文，使用父上下文的日志器
+//
+// 子上下文中找不到的Bean在父上下文中查找，同名Bean覆盖父上下文中的Bean；
+// 父上下文看不到子上下文中的Bean。父上下文中的Bean由父上下文初始化和销毁，
+// 应先启动父上下文，停止子上下文不会销毁父上下文中的Bean。子上下文与父上下文共享运行环境。
+func NewChildApplicationContext(parent *ApplicationContext) *ApplicationContext {
+	ctx := NewApplicationContextWithLogger(parent.GetLogger())
+	ctx.parent = parent
+	ctx.SetEnvironment(parent.environment)
+	ctx.container.SetParent(parent.container)
+	return ctx
+}
+
+// GetParent 获取父上下文，没有父上下文时返回nil
+func (ctx *ApplicationContext) GetParent() *ApplicationContext {
+	return ctx.parent
 }
 
 // RegisterBean 注册Bean
-func (ctx *ApplicationContext) RegisterBean(name string, instance interface{}) error {
-	// 检查是否为单例
-	typ := reflect.TypeOf(instance)
-	singleton := ctx.annotationUtils.IsSingleton(typ)
-
-	var err error
-	if singleton {
-		err = ctx.container.RegisterSingleton(name, instance)
-	} else {
-		err = ctx.container.RegisterPrototype(name, instance)
+func (ctx *ApplicationContext) RegisterBean(name string, instance interface{}, opts ...container.BeanOption) error {
+	// 按类型上声明的作用域注册
+	scope := ctx.annotationUtils.GetScope(reflect.TypeOf(instance))
+	if err := ctx.container.RegisterScoped(name, instance, scope, opts...); err != nil {
+		return err
 	}
 
-	if err != nil {
+	// 如果上下文已启动，立即处理生命周期，延迟Bean和非单例Bean在获取时处理
+	// 配置文件不成立时Bean未被注册
+	beanDef := ctx.container.GetBeanDefinition(name)
+	if ctx.started.Load() && beanDef != nil && beanDef.Singleton && !beanDef.Lazy {
+		return ctx.initializeBean(name, instance)
+	}
+
+	return nil
+}
+
+// RegisterSingleton 注册单例Bean，忽略类型上的作用域标签
+func (ctx *ApplicationContext) RegisterSingleton(name string, instance interface{}, opts ...container.BeanOption) error {
+	if err := ctx.container.RegisterSingleton(name, instance, opts...); err != nil {
 		return err
 	}
 
-	// 如果上下文已启动，立即处理生命周期
-	if ctx.started {
-		return ctx.lifecycleManager.ProcessInitialization(name, instance)
+	if ctx.started.Load() && !ctx.container.GetBeanDefinition(name).Lazy {
+		return ctx.initializeBean(name, instance)
 	}
 
 	return nil
 }
 
+// RegisterProvider 通过构造函数注册单例Bean，构造函数的参数按类型从容器中解析
+func (ctx *ApplicationContext) RegisterProvider(name string, fn interface{}, opts ...container.BeanOption) error {
+	if err := ctx.container.RegisterProvider(name, fn, opts...); err != nil {
+		return err
+	}
+
+	// 如果上下文已启动，立即创建并处理生命周期，延迟Bean在首次获取时处理
+	if ctx.started.Load() && !ctx.container.GetBeanDefinition(name).Lazy {
+		bean, err := ctx.container.LookupBean(name)
+		if err != nil {
+			return err
+		}
+		return ctx.initializeBean(name, bean)
+	}
+
+	return nil
+}
+
+// RegisterScope 以名称注册自定义作用域
+func (ctx *ApplicationContext) RegisterScope(name string, scope container.Scope) error {
+	return ctx.container.RegisterScope(name, scope)
+}
+
+// RegisterScoped 以指定作用域注册Bean，忽略类型上的作用域标签
+func (ctx *ApplicationContext) RegisterScoped(name string, instance interface{}, scope string, opts ...container.BeanOption) error {
+	if err := ctx.container.RegisterScoped(name, instance, scope, opts...); err != nil {
+		return err
+	}
+
+	if beanDef := ctx.container.GetBeanDefinition(name); ctx.started.Load() && beanDef.Singleton && !beanDef.Lazy {
+		return ctx.initializeBean(name, instance)
+	}
+
+	return nil
+}
+
+// DestroyScopedBean 从自定义作用域中移除Bean的当前实例并执行销毁回调
+func (ctx *ApplicationContext) DestroyScopedBean(name string) error {
+	return ctx.container.DestroyScopedBean(name)
+}
+
+// RegisterPrototypeProvider 通过构造函数注册原型Bean，每次获取都调用构造函数并执行初始化回调
+func (ctx *ApplicationContext) RegisterPrototypeProvider(name string, fn interface{}, opts ...container.BeanOption) error {
+	return ctx.container.RegisterPrototypeProvider(name, fn, opts...)
+}
+
+// DestroyBean 对不再使用的原型实例执行销毁回调
+func (ctx *ApplicationContext) DestroyBean(name string, instance interface{}) error {
+	return ctx.container.DestroyBean(name, instance)
+}
+
 // RegisterComponent 注册组件
 func (ctx *ApplicationContext) RegisterComponent(instance interface{}) error {
 	return ctx.scanner.ScanComponent(instance)
@@ -75,8 +175,8 @@ func (ctx *ApplicationContext) RegisterComponents(components ...interface{}) err
 }
 
 // RegisterByInterface 根据接口注册实现
-func (ctx *ApplicationContext) RegisterByInterface(interfaceType reflect.Type, implementation interface{}, name string) error {
-	return ctx.scanner.RegisterWithInterface(interfaceType, implementation, name)
+func (ctx *ApplicationContext) RegisterByInterface(interfaceType reflect.Type, implementation interface{}, name string, opts ...container.BeanOption) error {
+	return ctx.scanner.RegisterWithInterface(interfaceType, implementation, name, opts...)
 }
 
 // GetBean 获取Bean
@@ -89,7 +189,34 @@ func (ctx *ApplicationContext) GetBeanByType(typ reflect.Type) interface{} {
 	return ctx.container.GetBeanByType(typ)
 }
 
-// GetBeanT 泛型方式获取Bean（Go 1.18+）
+// LookupBean 获取Bean，Bean不存在或创建失败时返回错误
+func (ctx *ApplicationContext) LookupBean(name string) (interface{}, error) {
+	return ctx.container.LookupBean(name)
+}
+
+// LookupBeanByType 根据类型获取Bean，Bean不存在或创建失败时返回错误
+func (ctx *ApplicationContext) LookupBeanByType(typ reflect.Type) (interface{}, error) {
+	return ctx.container.LookupBeanByType(typ)
+}
+
+// LookupQualifiedBean 在可赋值给指定类型的Bean中按限定符获取Bean
+func (ctx *ApplicationContext) LookupQualifiedBean(typ reflect.Type, qualifier string) (interface{}, error) {
+	return ctx.container.LookupQualifiedBean(typ, qualifier)
+}
+
+// LookupBeanInContext 在指定上下文中获取Bean，请求作用域Bean从上下文的请求作用域中获取
+func (ctx *ApplicationContext) LookupBeanInContext(c stdcontext.Context, name string) (interface{}, error) {
+	return ctx.container.LookupBeanInContext(c, name)
+}
+
+// LookupQualifiedBeanInContext 在指定上下文中按类型和限定符获取Bean
+func (ctx *ApplicationContext) LookupQualifiedBeanInContext(c stdcontext.Context, typ reflect.Type, qualifier string) (interface{}, error) {
+	return ctx.container.LookupQualifiedBeanInContext(c, typ, qualifier)
+}
+
+// GetBeanT 泛型方式获取Bean（Go 1.18+），类型不匹配时返回零值
+//
+// 需要区分错误时使用 container.ResolveNamed[T]。
 func GetBeanT[T any](ctx *ApplicationContext, name string) T {
 	var zero T
 	bean := ctx.GetBean(name)
@@ -105,9 +232,11 @@ func GetBeanT[T any](ctx *ApplicationContext, name string) T {
 }
 
 // Start 启动应用上下文
+//
+// 启动失败时按初始化顺序的逆序销毁已经初始化的Bean，销毁中的错误与启动错误一起返回；已注册的Bean保留在容器中。
 func (ctx *ApplicationContext) Start() error {
-	if ctx.started {
-		return fmt.Errorf("application context is already started")
+	if ctx.started.Load() {
+		return ErrAlreadyStarted
 	}
 
 	start := time.Now()
@@ -117,38 +246,99 @@ func (ctx *ApplicationContext) Start() error {
 		Timestamp: time.Now(),
 	})
 
-	// 1. 执行依赖注入
-	if err := ctx.container.WireAll(); err != nil {
-		return fmt.Errorf("failed to wire dependencies: %v", err)
-	}
-
-	// 2. 处理所有Bean的生命周期初始化
-	beanNames := ctx.container.ListBeans()
-	for _, beanName := range beanNames {
-		bean := ctx.container.GetBean(beanName)
-		if bean != nil {
-			if err := ctx.lifecycleManager.ProcessInitialization(beanName, bean); err != nil {
-				return fmt.Errorf("failed to initialize bean '%s': %v", beanName, err)
-			}
+	componentCount, err := ctx.startBeans()
+	if err != nil {
+		if shutdownErr := ctx.destroyInitialized(); shutdownErr != nil {
+			return errors.Join(err, shutdownErr)
 		}
+		return err
 	}
 
-	ctx.started = true
+	ctx.started.Store(true)
 	
 	// 记录上下文启动完成事件
 	ctx.logger.LogEvent(&logging.ContextStarted{
 		Timestamp:      time.Now(),
 		Duration:       time.Since(start),
-		ComponentCount: len(beanNames),
+		ComponentCount: componentCount,
 	})
 	
 	return nil
 }
 
+// startBeans 完成装配并按依赖顺序初始化Bean，返回参与初始化的Bean数量
+func (ctx *ApplicationContext) startBeans() (int, error) {
+	// 不清空已初始化列表：启动前通过 GetBean 获取的延迟Bean已执行过初始化，
+	// 需要保留在列表中才能在 Stop 时被销毁
+	ctx.lifecycleManager.Reset()
+	ctx.mutex.Lock()
+	ctx.wiring = true
+	ctx.mutex.Unlock()
+
+	// 1. 移除注册条件不满足的Bean
+	ctx.container.EvaluateConditions(ctx.environment)
+
+	// 2. 使用容器中的 PropertyDecryptor 解密 ENC(...) 属性值
+	err := ctx.applyPropertyDecryptor()
+
+	// 3. 按依赖顺序执行依赖注入，同时检测循环依赖
+	if err == nil {
+		if err = ctx.container.WireAll(); err != nil {
+			err = fmt.Errorf("failed to wire dependencies: %w", err)
+		}
+	}
+
+	ctx.mutex.Lock()
+	ctx.wiring = false
+	ctx.mutex.Unlock()
+
+	if err != nil {
+		return 0, err
+	}
+
+	// 4. 计算初始化顺序
+	beanNames, err := ctx.container.InitializationOrder()
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve dependency order: %w", err)
+	}
+
+	// 5. 按依赖顺序处理所有Bean的生命周期初始化
+	// 原型Bean的每个实例在创建时初始化；延迟Bean只有在装配阶段已被其他Bean注入时
+	// 才在此初始化，其余在首次获取时初始化
+	for _, beanName := range beanNames {
+		beanDef := ctx.container.GetBeanDefinition(beanName)
+		if !beanDef.Singleton {
+			continue
+		}
+		if beanDef.Lazy && (!beanDef.IsLazyInitialized() || ctx.isInitialized(beanName)) {
+			continue
+		}
+
+		bean := ctx.container.GetBean(beanName)
+		if bean != nil {
+			if err := ctx.initializeBean(beanName, bean); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return len(beanNames), nil
+}
+
 // Stop 停止应用上下文
+//
+// 按初始化顺序的逆序销毁Bean，每个Bean受 SetDestroyTimeout 或Bean自身的销毁时限约束，
+// 全部Bean受 SetShutdownTimeout 的总时限约束。有Bean销毁失败或超时时仍会完成停止，
+// 记录在 ContextStopped 事件中，并返回 *ShutdownError。
+//
+// 超时的销毁回调不会被中断：Stop 不再等待它，继续销毁其余Bean并销毁容器，回调在后台与之同时执行，
+// Stop 返回时可能仍未结束。接收 context.Context 的回调可以在上下文结束时提前返回。
 func (ctx *ApplicationContext) Stop() error {
-	if !ctx.started {
-		return fmt.Errorf("application context is not started")
+	ctx.stopMutex.Lock()
+	defer ctx.stopMutex.Unlock()
+
+	if !ctx.started.Load() {
+		return ErrNotStarted
 	}
 
 	start := time.Now()
@@ -158,35 +348,116 @@ func (ctx *ApplicationContext) Stop() error {
 		Timestamp: time.Now(),
 	})
 
-	// 按逆序销毁Bean
-	beanNames := ctx.container.ListBeans()
-	for i := len(beanNames) - 1; i >= 0; i-- {
-		beanName := beanNames[i]
-		bean := ctx.container.GetBean(beanName)
-		if bean != nil {
-			if err := ctx.lifecycleManager.ProcessDestruction(beanName, bean); err != nil {
-				// 记录错误但继续销毁其他Bean
-				fmt.Printf("Error destroying bean '%s': %v\n", beanName, err)
-			}
-		}
-	}
+	ctx.stopWatchingConfig()
+	ctx.removeShutdownHook()
 
-	// 销毁容器
+	shutdownErr := ctx.destroyInitialized()
 	ctx.container.Destroy()
-	ctx.started = false
+	ctx.started.Store(false)
 
 	// 记录上下文停止完成事件
-	ctx.logger.LogEvent(&logging.ContextStopped{
+	stopped := &logging.ContextStopped{
 		Timestamp: time.Now(),
 		Duration:  time.Since(start),
-	})
+	}
+	if shutdownErr != nil {
+		stopped.FailedBeans = shutdownErr.Failed
+		stopped.TimedOutBeans = shutdownErr.TimedOut
+	}
+	ctx.logger.LogEvent(stopped)
 
+	if shutdownErr != nil {
+		return shutdownErr
+	}
 	return nil
 }
 
+// destroyInitialized 按初始化顺序的逆序销毁已初始化的Bean并清空初始化记录
+func (ctx *ApplicationContext) destroyInitialized() *ShutdownError {
+	ctx.mutex.Lock()
+	initialized := ctx.initialized
+	initializedBeans := ctx.initializedBeans
+	ctx.mutex.Unlock()
+
+	shutdownErr := ctx.destroyBeans(initialized, initializedBeans)
+
+	ctx.mutex.Lock()
+	ctx.initialized = nil
+	ctx.initializedBeans = nil
+	ctx.mutex.Unlock()
+
+	return shutdownErr
+}
+
+// initializeBean 处理Bean的生命周期初始化并记录初始化顺序
+func (ctx *ApplicationContext) initializeBean(name string, bean interface{}) error {
+	ctx.applyAware(bean)
+	if err := ctx.lifecycleManager.ProcessInitialization(name, bean); err != nil {
+		return err
+	}
+
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+
+	if ctx.initializedBeans == nil {
+		ctx.initializedBeans = make(map[string]interface{})
+	}
+	ctx.initialized = append(ctx.initialized, name)
+	ctx.initializedBeans[name] = bean
+	return nil
+}
+
+// initializeLazyBean 延迟Bean首次创建后执行生命周期初始化
+//
+// 装配阶段被其他Bean直接注入的延迟Bean由 Start 按依赖顺序统一初始化。
+func (ctx *ApplicationContext) initializeLazyBean(name string, bean interface{}) error {
+	ctx.mutex.Lock()
+	wiring := ctx.wiring
+	ctx.mutex.Unlock()
+
+	if wiring {
+		return nil
+	}
+	return ctx.initializeBean(name, bean)
+}
+
+// contextLifecycle 将容器中延迟Bean、原型Bean和自定义作用域Bean的生命周期回调交给上下文处理
+type contextLifecycle struct {
+	ctx *ApplicationContext
+}
+
+func (l contextLifecycle) InitializeBean(name string, bean interface{}) error {
+	return l.ctx.initializeLazyBean(name, bean)
+}
+
+func (l contextLifecycle) InitializeInstance(name string, bean interface{}) error {
+	l.ctx.applyAware(bean)
+	return l.ctx.lifecycleManager.InitializeInstance(name, bean)
+}
+
+// applyAware 在初始化回调之前向感知接口提供上下文的运行环境
+func (ctx *ApplicationContext) applyAware(bean interface{}) {
+	if aware, ok := bean.(environment.EnvironmentAware); ok {
+		aware.SetEnvironment(ctx.environment)
+	}
+}
+
+func (l contextLifecycle) DestroyInstance(name string, bean interface{}) error {
+	return l.ctx.lifecycleManager.DestroyInstance(name, bean)
+}
+
+// isInitialized 检查Bean是否已完成生命周期初始化
+func (ctx *ApplicationContext) isInitialized(name string) bool {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+
+	_, exists := ctx.initializedBeans[name]
+	return exists
+}
+
 // Refresh 刷新上下文
 func (ctx *ApplicationContext) Refresh() error {
-	if ctx.started {
+	if ctx.started.Load() {
 		if err := ctx.Stop(); err != nil {
 			return err
 		}
@@ -196,24 +467,55 @@ func (ctx *ApplicationContext) Refresh() error {
 
 // IsStarted 检查上下文是否已启动
 func (ctx *ApplicationContext) IsStarted() bool {
-	return ctx.started
+	return ctx.started.Load()
 }
 
-// HasBean 检查是否存在指定Bean
+// HasBean 检查本上下文或父上下文中是否存在指定Bean
 func (ctx *ApplicationContext) HasBean(name string) bool {
 	return ctx.container.HasBean(name)
 }
 
-// ListBeans 列出所有Bean名称
+// HasLocalBean 检查本上下文中是否存在指定Bean，不查找父上下文
+func (ctx *ApplicationContext) HasLocalBean(name string) bool {
+	return ctx.container.HasLocalBean(name)
+}
+
+// ListBeans 列出本上下文中的Bean名称
 func (ctx *ApplicationContext) ListBeans() []string {
 	return ctx.container.ListBeans()
 }
 
+// ListBeansIncludingAncestors 列出本上下文及所有父上下文中的Bean名称
+func (ctx *ApplicationContext) ListBeansIncludingAncestors() []string {
+	return ctx.container.ListBeansIncludingAncestors()
+}
+
 // GetBeanDefinition 获取Bean定义
 func (ctx *ApplicationContext) GetBeanDefinition(name string) *container.BeanDefinition {
 	return ctx.container.GetBeanDefinition(name)
 }
 
+// GetEnvironment 获取运行环境，注册条件在 Start 时基于它判断
+func (ctx *ApplicationContext) GetEnvironment() *environment.StandardEnvironment {
+	return ctx.environment
+}
+
+// SetEnvironment 设置运行环境，需在注册Bean之前调用，profile 声明在注册时判断
+func (ctx *ApplicationContext) SetEnvironment(env *environment.StandardEnvironment) {
+	ctx.environment = env
+	ctx.container.SetEnvironment(env)
+}
+
+// GetActiveProfiles 获取激活的配置文件
+func (ctx *ApplicationContext) GetActiveProfiles() []string {
+	return ctx.environment.GetActiveProfiles()
+}
+
+// SetActiveProfiles 设置激活的配置文件，需在注册Bean之前调用
+func (ctx *ApplicationContext) SetActiveProfiles(profiles ...string) {
+	ctx.environment.SetActiveProfiles(profiles...)
+}
+
 // GetContainer 获取底层容器
 func (ctx *ApplicationContext) GetContainer() *container.Container {
 	return ctx.container
@@ -235,6 +537,31 @@ func (ctx *ApplicationContext) CreateBean(name string, factory func() interface{
 	return ctx.RegisterBean(name, instance)
 }
 
+// SetStrictWiring 设置是否启用严格装配模式（默认启用），严格模式下无法满足的依赖会使 Start 失败
+func (ctx *ApplicationContext) SetStrictWiring(strict bool) {
+	ctx.container.SetStrictWiring(strict)
+}
+
+// SetAllowCircularReferences 设置是否允许单例Bean之间通过字段注入形成循环依赖
+func (ctx *ApplicationContext) SetAllowCircularReferences(allow bool) {
+	ctx.container.SetAllowCircularReferences(allow)
+}
+
+// SetOverridePolicy 设置注册同名Bean时的处理策略，覆盖应在 Start 之前完成
+func (ctx *ApplicationContext) SetOverridePolicy(policy container.OverridePolicy) {
+	ctx.container.SetOverridePolicy(policy)
+}
+
+// RegisterAlias 为Bean注册别名
+func (ctx *ApplicationContext) RegisterAlias(alias, name string) error {
+	return ctx.container.RegisterAlias(alias, name)
+}
+
+// GetAliases 获取指向该Bean的所有别名
+func (ctx *ApplicationContext) GetAliases(name string) []string {
+	return ctx.container.GetAliases(name)
+}
+
 // SetLogger 设置应用上下文的日志器
 func (ctx *ApplicationContext) SetLogger(logger logging.Logger) {
 	ctx.logger = logger
@@ -246,20 +573,19 @@ func (ctx *ApplicationContext) GetLogger() logging.Logger {
 	return ctx.logger
 }
 
-// GetBeansOfType 获取指定类型的所有Bean
+// GetBeansOfType 获取本上下文中指定类型的所有Bean
+//
+// 返回的映射没有顺序；需要按排序值遍历时使用 GetBeanNamesForType 返回的名称顺序，或 container.ResolveAll。
 func (ctx *ApplicationContext) GetBeansOfType(typ reflect.Type) map[string]interface{} {
-	result := make(map[string]interface{})
-	beanNames := ctx.container.ListBeans()
-	
-	for _, beanName := range beanNames {
-		bean := ctx.container.GetBean(beanName)
-		if bean != nil {
-			beanType := reflect.TypeOf(bean)
-			if beanType.AssignableTo(typ) || beanType.Implements(typ) {
-				result[beanName] = bean
-			}
-		}
-	}
-	
-	return result
-}
\ No newline at end of file
+	return ctx.container.GetBeansOfType(typ)
+}
+
+// GetBeansOfTypeIncludingAncestors 获取本上下文及所有父上下文中指定类型的Bean
+func (ctx *ApplicationContext) GetBeansOfTypeIncludingAncestors(typ reflect.Type) map[string]interface{} {
+	return ctx.container.GetBeansOfTypeIncludingAncestors(typ)
+}
+
+// GetBeanNamesForType 按排序值返回所有可赋值给指定类型的Bean名称，排序值相同时按注册顺序
+func (ctx *ApplicationContext) GetBeanNamesForType(typ reflect.Type) []string {
+	return ctx.container.GetBeanNamesForType(typ)
+}
@@ -0,0 +1,117 @@
+package context
+
+import (
+	"time"
+
+	"gospring/environment"
+	"gospring/logging"
+)
+
+// RefreshConfig 重新加载发生变化的属性源并应用新的配置
+//
+// 属性有变化时依次重新绑定所有配置对象（全部成功才以新的实例替换）、结束刷新作用域中的实例，
+// 记录 ConfigChanged 事件，并按初始化顺序通知实现 environment.RefreshListener 的单例。
+// 已经注入的配置对象不会被修改，需要最新配置的Bean通过 container.Provider[T] 获取。
+// 没有变化时返回nil。重新绑定失败时配置对象保持原值、不通知监听器，
+// 返回属性的变化和 *environment.BindingError。
+func (ctx *ApplicationContext) RefreshConfig() (*environment.ConfigChanged, error) {
+	event, reloadErr := ctx.environment.Refresh()
+	if event == nil {
+		if reloadErr != nil {
+			ctx.logger.LogEvent(&logging.ConfigChanged{Timestamp: time.Now(), Error: reloadErr})
+		}
+		return nil, reloadErr
+	}
+
+	if err := ctx.container.RebindConfigProperties(); err != nil {
+		ctx.logger.LogEvent(&logging.ConfigChanged{
+			Timestamp: time.Now(),
+			Sources:   event.Sources,
+			Keys:      event.Keys,
+			Error:     err,
+		})
+		return event, err
+	}
+	ctx.replaceConfigBeans()
+	ctx.container.RefreshScope()
+
+	ctx.logger.LogEvent(&logging.ConfigChanged{
+		Timestamp: time.Now(),
+		Sources:   event.Sources,
+		Keys:      event.Keys,
+		Error:     reloadErr,
+	})
+
+	ctx.mutex.Lock()
+	initialized := append([]string(nil), ctx.initialized...)
+	initializedBeans := ctx.initializedBeans
+	ctx.mutex.Unlock()
+
+	for _, name := range initialized {
+		if listener, ok := initializedBeans[name].(environment.RefreshListener); ok {
+			listener.OnConfigChanged(event)
+		}
+	}
+	return event, reloadErr
+}
+
+// replaceConfigBeans 将已初始化的配置对象替换为重新绑定后的实例，停止时销毁和通知监听器使用新的实例
+func (ctx *ApplicationContext) replaceConfigBeans() {
+	ctx.mutex.Lock()
+	initialized := append([]string(nil), ctx.initialized...)
+	ctx.mutex.Unlock()
+
+	replaced := make(map[string]interface{})
+	for _, name := range initialized {
+		if beanDef := ctx.container.GetBeanDefinition(name); beanDef != nil && beanDef.ConfigPrefix != "" {
+			replaced[name] = ctx.container.GetBean(name)
+		}
+	}
+
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+	for name, bean := range replaced {
+		if _, exists := ctx.initializedBeans[name]; exists {
+			ctx.initializedBeans[name] = bean
+		}
+	}
+}
+
+// WatchConfig 在后台每隔 interval 调用一次 RefreshConfig，直到上下文停止
+//
+// 通过 LoadConfigFiles 加载的配置文件修改后即可被检测到。重复调用时先停止之前的轮询；
+// 后台刷新的错误记录在 ConfigChanged 事件中。
+func (ctx *ApplicationContext) WatchConfig(interval time.Duration) {
+	stop := make(chan struct{})
+
+	ctx.mutex.Lock()
+	if ctx.stopWatching != nil {
+		close(ctx.stopWatching)
+	}
+	ctx.stopWatching = stop
+	ctx.mutex.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				ctx.RefreshConfig()
+			}
+		}
+	}()
+}
+
+// stopWatchingConfig 停止 WatchConfig 启动的轮询
+func (ctx *ApplicationContext) stopWatchingConfig() {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+
+	if ctx.stopWatching != nil {
+		close(ctx.stopWatching)
+		ctx.stopWatching = nil
+	}
+}
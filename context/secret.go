@@ -0,0 +1,26 @@
+package context
+
+import (
+	"fmt"
+	"reflect"
+
+	"gospring/environment"
+)
+
+// applyPropertyDecryptor 将实现 environment.PropertyDecryptor 的Bean设置为运行环境的解密器
+//
+// 没有这样的Bean时保留通过 GetEnvironment().SetDecryptor 设置的解密器。
+// 解密器在依赖注入之前获取，因此不应依赖其他Bean。
+func (ctx *ApplicationContext) applyPropertyDecryptor() error {
+	typ := reflect.TypeOf((*environment.PropertyDecryptor)(nil)).Elem()
+	if len(ctx.container.GetBeanNamesForType(typ)) == 0 {
+		return nil
+	}
+
+	decryptor, err := ctx.container.LookupBeanByType(typ)
+	if err != nil {
+		return fmt.Errorf("failed to resolve property decryptor: %w", err)
+	}
+	ctx.environment.SetDecryptor(decryptor.(environment.PropertyDecryptor))
+	return nil
+}
@@ -0,0 +1,170 @@
+package context
+
+import (
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrShutdown 用于 errors.Is 判断停止上下文时有Bean销毁失败或超时
+var ErrShutdown = errors.New("application context shutdown incomplete")
+
+// ShutdownError 停止上下文时销毁失败或超时的Bean
+type ShutdownError struct {
+	Failed   []string         // 销毁回调返回错误的Bean，按销毁顺序排列
+	TimedOut []string         // 销毁超时或因总时限耗尽未销毁的Bean，按销毁顺序排列；超时的回调可能仍在后台执行
+	Errors   map[string]error // 销毁失败的原因
+}
+
+func (e *ShutdownError) Error() string {
+	var parts []string
+	for _, name := range e.Failed {
+		parts = append(parts, e.Errors[name].Error())
+	}
+	if len(e.TimedOut) > 0 {
+		parts = append(parts, fmt.Sprintf("beans timed out: %s", strings.Join(e.TimedOut, ", ")))
+	}
+	return fmt.Sprintf("%v: %s", ErrShutdown, strings.Join(parts, "; "))
+}
+
+func (e *ShutdownError) Is(target error) bool {
+	return target == ErrShutdown
+}
+
+func (e *ShutdownError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, name := range e.Failed {
+		errs = append(errs, e.Errors[name])
+	}
+	return errs
+}
+
+// SetShutdownTimeout 设置 Stop 销毁所有Bean的总时限，为0时不限制
+//
+// 总时限耗尽时正在销毁的Bean不再等待，剩余的Bean不再销毁，都记录为超时。
+func (ctx *ApplicationContext) SetShutdownTimeout(timeout time.Duration) {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+	ctx.shutdownTimeout = timeout
+}
+
+// SetDestroyTimeout 设置销毁单个Bean的默认时限，为0时不限制
+//
+// Bean可以通过 destroyTimeout 标签或 container.DestroyTimeout 选项设置自己的时限。
+func (ctx *ApplicationContext) SetDestroyTimeout(timeout time.Duration) {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+	ctx.destroyTimeout = timeout
+}
+
+// RegisterShutdownHook 收到信号时停止上下文，未指定信号时监听 SIGINT 和 SIGTERM
+//
+// 返回的通道在钩子停止上下文后接收 Stop 的结果并关闭；上下文以其他方式停止时钩子被撤销，通道直接关闭。
+// 收到信号后恢复信号的默认处理，销毁过程中再次发送信号可以强制结束进程。重复调用时替换之前的钩子。
+//
+//	ctx.Start()
+//	if err := <-ctx.RegisterShutdownHook(); err != nil {
+//		log.Fatal(err)
+//	}
+func (ctx *ApplicationContext) RegisterShutdownHook(signals ...os.Signal) <-chan error {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, signals...)
+
+	remove := make(chan struct{})
+	result := make(chan error, 1)
+
+	ctx.mutex.Lock()
+	if ctx.stopHook != nil {
+		close(ctx.stopHook)
+	}
+	ctx.stopHook = remove
+	ctx.mutex.Unlock()
+
+	go func() {
+		defer close(result)
+		select {
+		case <-received:
+			signal.Stop(received)
+			result <- ctx.Stop()
+		case <-remove:
+			signal.Stop(received)
+		}
+	}()
+	return result
+}
+
+// removeShutdownHook 撤销 RegisterShutdownHook 注册的钩子
+func (ctx *ApplicationContext) removeShutdownHook() {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+
+	if ctx.stopHook != nil {
+		close(ctx.stopHook)
+		ctx.stopHook = nil
+	}
+}
+
+// destroyBeans 按 names 的逆序在时限内销毁Bean，全部成功时返回nil
+func (ctx *ApplicationContext) destroyBeans(names []string, beans map[string]interface{}) *ShutdownError {
+	ctx.mutex.Lock()
+	shutdownTimeout, destroyTimeout := ctx.shutdownTimeout, ctx.destroyTimeout
+	ctx.mutex.Unlock()
+
+	shutdownCtx := stdcontext.Background()
+	if shutdownTimeout > 0 {
+		var cancel stdcontext.CancelFunc
+		shutdownCtx, cancel = stdcontext.WithTimeout(shutdownCtx, shutdownTimeout)
+		defer cancel()
+	}
+
+	result := &ShutdownError{Errors: make(map[string]error)}
+	for i := len(names) - 1; i >= 0; i-- {
+		name := names[i]
+		// 总时限耗尽后剩余的Bean不再销毁
+		if shutdownCtx.Err() != nil {
+			result.TimedOut = append(result.TimedOut, name)
+			continue
+		}
+
+		timedOut, err := ctx.destroyBean(shutdownCtx, destroyTimeout, name, beans[name])
+		switch {
+		case err == nil:
+		case timedOut:
+			result.TimedOut = append(result.TimedOut, name)
+		default:
+			result.Failed = append(result.Failed, name)
+			result.Errors[name] = err
+		}
+	}
+
+	if len(result.Failed) == 0 && len(result.TimedOut) == 0 {
+		return nil
+	}
+	return result
+}
+
+// destroyBean 销毁单个Bean，Bean声明了销毁时限时覆盖默认时限
+//
+// 销毁错误由传给回调的上下文结束引起时视为超时；上下文结束时回调返回的其他错误，
+// 以及上下文没有结束时回调自己返回的 context.DeadlineExceeded，仍然是销毁失败。
+func (ctx *ApplicationContext) destroyBean(parent stdcontext.Context, timeout time.Duration, name string, bean interface{}) (timedOut bool, err error) {
+	if beanDef := ctx.container.GetBeanDefinition(name); beanDef != nil && beanDef.DestroyTimeout > 0 {
+		timeout = beanDef.DestroyTimeout
+	}
+	destroyCtx := parent
+	if timeout > 0 {
+		var cancel stdcontext.CancelFunc
+		destroyCtx, cancel = stdcontext.WithTimeout(parent, timeout)
+		defer cancel()
+	}
+	err = ctx.lifecycleManager.ProcessDestructionContext(destroyCtx, name, bean)
+	return err != nil && destroyCtx.Err() != nil && errors.Is(err, destroyCtx.Err()), err
+}
@@ -0,0 +1,354 @@
+package environment
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Bind 将前缀下的属性绑定到结构体指针 target
+//
+// 字段名转换为短横线形式作为属性名，例如前缀 server 下的 Timeouts.ReadTimeout 字段
+// 对应 server.timeouts.read-timeout，属性名按宽松规则匹配。
+// 字段上的 config:"name" 标签指定属性名，config:"-" 跳过该字段，带有 inject 标签的字段由容器注入，不参与绑定。
+// 嵌套结构体和结构体指针递归绑定，切片绑定 key[0]、key[1] 或逗号分隔的列表，
+// 映射绑定 key.<name> 形式的属性或 a=1,b=2 形式的列表。
+// 属性值中的占位符会被解析，ENC(...) 密文会被解密，见 ResolvePlaceholders。
+//
+// 属性不存在且字段为零值时使用 default 标签的值；validate 标签声明校验规则，以逗号分隔：
+//
+//	required       不能为零值或空
+//	min=N, max=N   数值的范围，字符串、切片和映射的长度范围；时长可写为 min=1s
+//	oneof=a b c    值必须是列出的值之一
+//
+// 所有无法转换或校验失败的属性汇总为一个 *BindingError 返回。
+func Bind(env Environment, prefix string, target interface{}) error {
+	val := reflect.ValueOf(target)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind target must be a non-nil pointer to struct, got %T", target)
+	}
+
+	b := &binder{env: env}
+	if named, ok := env.(interface{ PropertyNames() []string }); ok {
+		b.names = named.PropertyNames()
+	}
+	b.bindStruct(prefix, val.Elem(), val.Elem().Type().String())
+
+	if len(b.errors) > 0 {
+		return &BindingError{Errors: b.errors}
+	}
+	return nil
+}
+
+// binder 一次绑定过程，names 用于查找切片下标和映射的键
+type binder struct {
+	env    Environment
+	names  []string
+	errors []*InvalidPropertyError
+}
+
+// bindStruct 绑定结构体的导出字段，返回是否有属性被绑定
+func (b *binder) bindStruct(prefix string, val reflect.Value, target string) bool {
+	typ := val.Type()
+	bound := false
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if _, injected := field.Tag.Lookup("inject"); field.PkgPath != "" || field.Name == "_" || injected {
+			continue
+		}
+
+		name := propertyName(field.Name)
+		if tag := field.Tag.Get("config"); tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+
+		if b.bindField(joinKey(prefix, name), val.Field(i), field, target+"."+field.Name) {
+			bound = true
+		}
+	}
+	return bound
+}
+
+// bindField 绑定单个字段，属性不存在时使用默认值，转换成功后执行校验
+func (b *binder) bindField(key string, value reflect.Value, field reflect.StructField, target string) bool {
+	failures := len(b.errors)
+	bound := b.bindValue(key, value, target)
+
+	if defaultValue, ok := field.Tag.Lookup("default"); ok && !bound && value.IsZero() {
+		b.set(key, defaultValue, value, target)
+	}
+
+	if rules := field.Tag.Get("validate"); rules != "" && len(b.errors) == failures {
+		if err := validate(value, rules); err != nil {
+			b.invalid(key, formatValue(value), target, err)
+		}
+	}
+	return bound
+}
+
+// bindValue 按字段类型绑定属性，返回是否存在对应的属性
+func (b *binder) bindValue(key string, value reflect.Value, target string) bool {
+	typ := value.Type()
+	switch {
+	case !isComposite(typ):
+		raw, exists := b.env.GetProperty(key)
+		if exists {
+			b.set(key, raw, value, target)
+		}
+		return exists
+
+	case typ.Kind() == reflect.Struct:
+		return b.bindStruct(key, value, target)
+
+	case typ.Kind() == reflect.Ptr:
+		elem := value
+		if value.IsNil() {
+			elem = reflect.New(typ.Elem())
+		}
+		bound := b.bindStruct(key, elem.Elem(), target)
+		if bound && value.IsNil() {
+			value.Set(elem)
+		}
+		return bound
+
+	case typ.Kind() == reflect.Slice:
+		// 元素为标量时优先使用逗号分隔的列表，便于环境变量覆盖整个列表
+		if raw, exists := b.env.GetProperty(key); exists && !isComposite(typ.Elem()) {
+			b.set(key, raw, value, target)
+			return true
+		}
+		indices := b.indices(key)
+		if len(indices) == 0 {
+			return false
+		}
+		slice := reflect.MakeSlice(typ, indices[len(indices)-1]+1, indices[len(indices)-1]+1)
+		for _, i := range indices {
+			b.bindValue(fmt.Sprintf("%s[%d]", key, i), slice.Index(i), fmt.Sprintf("%s[%d]", target, i))
+		}
+		value.Set(slice)
+		return true
+
+	default: // reflect.Map
+		if raw, exists := b.env.GetProperty(key); exists && !isComposite(typ.Elem()) {
+			b.set(key, raw, value, target)
+			return true
+		}
+		keys := b.mapKeys(key, isComposite(typ.Elem()))
+		if len(keys) == 0 {
+			return false
+		}
+		if value.IsNil() {
+			value.Set(reflect.MakeMap(typ))
+		}
+		for _, k := range keys {
+			mapKey, err := Convert(k, typ.Key())
+			if err != nil {
+				b.invalid(joinKey(key, k), k, target, err)
+				continue
+			}
+			elem := reflect.New(typ.Elem()).Elem()
+			if existing := value.MapIndex(mapKey); existing.IsValid() {
+				elem.Set(existing)
+			}
+			b.bindValue(joinKey(key, k), elem, fmt.Sprintf("%s[%s]", target, k))
+			value.SetMapIndex(mapKey, elem)
+		}
+		return true
+	}
+}
+
+// set 解密、解析占位符并转换属性值，失败时记录无效属性
+func (b *binder) set(key, raw string, value reflect.Value, target string) {
+	resolved, err := resolveValue(b.env, key, raw, nil)
+	if err != nil {
+		b.invalid(key, raw, target, err)
+		return
+	}
+	converted, err := Convert(resolved, value.Type())
+	if err != nil {
+		masked := MaskValue(b.env, key, resolved)
+		b.invalid(key, masked, target, &ConversionError{Key: key, Value: masked, TargetType: value.Type(), Cause: err})
+		return
+	}
+	value.Set(converted)
+}
+
+// invalid 记录无效属性，敏感属性的值被遮盖
+func (b *binder) invalid(key, value, target string, err error) {
+	b.errors = append(b.errors, &InvalidPropertyError{Key: key, Value: MaskValue(b.env, key, value), Target: target, Cause: err})
+}
+
+// indices 查找 key[i] 形式的属性下标，按升序返回
+func (b *binder) indices(key string) []int {
+	prefix := canonicalKey(key) + "["
+	seen := make(map[int]bool)
+	var indices []int
+	for _, name := range b.names {
+		canonical := canonicalKey(name)
+		if !strings.HasPrefix(canonical, prefix) {
+			continue
+		}
+		end := strings.IndexByte(canonical[len(prefix):], ']')
+		if end < 0 {
+			continue
+		}
+		if i, err := strconv.Atoi(canonical[len(prefix) : len(prefix)+end]); err == nil && i >= 0 && !seen[i] {
+			seen[i] = true
+			indices = append(indices, i)
+		}
+	}
+	sort.Ints(indices)
+	return indices
+}
+
+// mapKeys 查找 key.<name> 形式的属性中的映射键，按字母顺序返回
+//
+// 值为结构体等复合类型时映射键只取到下一个 . 或 [ 之前，否则取剩余的整个属性名。
+// 属性名与前缀只有大小写不同时保留原属性名中的大小写。
+func (b *binder) mapKeys(key string, composite bool) []string {
+	prefix := canonicalKey(key) + "."
+	seen := make(map[string]bool)
+	var keys []string
+	for _, name := range b.names {
+		if !strings.HasPrefix(canonicalKey(name), prefix) {
+			continue
+		}
+
+		var rest string
+		if strings.HasPrefix(strings.ToLower(name), strings.ToLower(key)+".") {
+			rest = name[len(key)+1:]
+		} else {
+			rest = canonicalKey(name)[len(prefix):]
+		}
+		if composite {
+			if end := strings.IndexAny(rest, ".["); end >= 0 {
+				rest = rest[:end]
+			}
+		}
+
+		if rest != "" && !seen[rest] {
+			seen[rest] = true
+			keys = append(keys, rest)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// propertyName 将字段名转换为短横线形式的属性名，例如 PoolSize 为 pool-size，URLPath 为 url-path
+func propertyName(fieldName string) string {
+	runes := []rune(fieldName)
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 &&
+			(!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			sb.WriteByte('-')
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
+
+// isComposite 检查类型是否需要按嵌套属性绑定，标量类型直接由 Convert 转换
+func isComposite(typ reflect.Type) bool {
+	if reflect.PointerTo(typ).Implements(textUnmarshalerType) || typ.Implements(textUnmarshalerType) {
+		return false
+	}
+	switch typ.Kind() {
+	case reflect.Struct, reflect.Map:
+		return true
+	case reflect.Slice:
+		return typ.Elem().Kind() != reflect.Uint8
+	case reflect.Ptr:
+		return typ.Elem().Kind() == reflect.Struct
+	}
+	return false
+}
+
+// validate 按 validate 标签的规则校验字段值
+func validate(value reflect.Value, rules string) error {
+	for _, rule := range strings.Split(rules, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch name {
+		case "":
+		case "required":
+			if value.IsZero() || hasLength(value) && value.Len() == 0 {
+				return fmt.Errorf("is required")
+			}
+		case "min", "max":
+			cmp, err := compare(value, arg)
+			if err != nil {
+				return fmt.Errorf("invalid %s rule: %w", name, err)
+			}
+			if name == "min" && cmp < 0 {
+				return fmt.Errorf("must be at least %s", arg)
+			}
+			if name == "max" && cmp > 0 {
+				return fmt.Errorf("must be at most %s", arg)
+			}
+		case "oneof":
+			if !contains(strings.Fields(arg), formatValue(value)) {
+				return fmt.Errorf("must be one of [%s]", arg)
+			}
+		default:
+			return fmt.Errorf("unknown validation rule '%s'", name)
+		}
+	}
+	return nil
+}
+
+// compare 比较字段值与界限，字符串、切片和映射比较长度
+func compare(value reflect.Value, bound string) (int, error) {
+	if hasLength(value) {
+		n, err := strconv.Atoi(bound)
+		if err != nil {
+			return 0, err
+		}
+		return compareOrdered(value.Len(), n), nil
+	}
+
+	limit, err := Convert(bound, value.Type())
+	if err != nil {
+		return 0, err
+	}
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareOrdered(value.Int(), limit.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return compareOrdered(value.Uint(), limit.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return compareOrdered(value.Float(), limit.Float()), nil
+	}
+	return 0, fmt.Errorf("type %v cannot be compared", value.Type())
+}
+
+func compareOrdered[T int | int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func hasLength(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return true
+	}
+	return false
+}
+
+// formatValue 错误信息中显示的字段值
+func formatValue(value reflect.Value) string {
+	if value.Kind() == reflect.Ptr && value.IsNil() {
+		return ""
+	}
+	return fmt.Sprint(value.Interface())
+}
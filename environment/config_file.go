@@ -0,0 +1,221 @@
+package environment
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ConfigNameProperty 配置文件的基础名称，默认为 application
+	ConfigNameProperty = "gospring.config.name"
+	// ConfigLocationProperty 查找配置文件的目录或文件，多个位置以逗号分隔
+	ConfigLocationProperty = "gospring.config.location"
+	// DefaultConfigName 默认的配置文件基础名称
+	DefaultConfigName = "application"
+)
+
+// DefaultConfigLocations 未指定位置时查找配置文件的目录，靠后的目录优先
+var DefaultConfigLocations = []string{".", "config"}
+
+// configExtensions 支持的配置文件扩展名，同一目录下存在多种格式时靠前的优先
+var configExtensions = []string{".properties", ".yaml", ".yml", ".json", ".toml"}
+
+// LoadConfigFile 按扩展名读取 .properties、.yaml、.yml、.json 或 .toml 配置文件
+//
+// 嵌套的文档展开为点分隔的属性名，例如 server: {port: 8080} 得到 server.port=8080；
+// 列表元素以 key[0]、key[1] 命名，元素都是标量的列表同时以逗号连接保存为 key。
+// 属性源以文件路径命名。
+func LoadConfigFile(path string) (*MapPropertySource, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".properties" {
+		return LoadPropertiesFile(path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var document interface{}
+	switch ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &document)
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		err = decoder.Decode(&document)
+	case ".toml":
+		var table map[string]interface{}
+		_, err = toml.Decode(string(data), &table)
+		document = table
+	default:
+		return nil, fmt.Errorf("unsupported config file format: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	properties := make(map[string]string)
+	switch document.(type) {
+	case nil:
+	case map[string]interface{}:
+		flatten("", document, properties)
+	default:
+		return nil, fmt.Errorf("%s: top-level value must be a mapping", path)
+	}
+	return NewMapPropertySource(path, properties), nil
+}
+
+// flatten 将嵌套的文档展开为点分隔的属性
+func flatten(prefix string, value interface{}, properties map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			flatten(joinKey(prefix, key), item, properties)
+		}
+	case map[interface{}]interface{}:
+		for key, item := range v {
+			flatten(joinKey(prefix, fmt.Sprint(key)), item, properties)
+		}
+	case []map[string]interface{}:
+		// TOML 的表数组
+		for i, item := range v {
+			flatten(fmt.Sprintf("%s[%d]", prefix, i), item, properties)
+		}
+	case []interface{}:
+		scalars := make([]string, 0, len(v))
+		for i, item := range v {
+			flatten(fmt.Sprintf("%s[%d]", prefix, i), item, properties)
+			switch item.(type) {
+			case map[string]interface{}, map[interface{}]interface{}, []interface{}:
+			default:
+				scalars = append(scalars, properties[fmt.Sprintf("%s[%d]", prefix, i)])
+			}
+		}
+		if len(scalars) == len(v) {
+			properties[prefix] = strings.Join(scalars, ",")
+		}
+	case nil:
+		properties[prefix] = ""
+	case time.Time:
+		properties[prefix] = v.Format(time.RFC3339Nano)
+	default:
+		properties[prefix] = fmt.Sprint(v)
+	}
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// LoadConfigFiles 加载配置文件，并在环境变量之后按优先级添加为属性源
+//
+// 在每个位置查找 application.<ext>，以及每个激活的配置文件对应的
+// application-<profile>.<ext>（没有激活的配置文件时使用默认配置文件）。
+// 未指定 locations 时读取 gospring.config.location 属性，仍未设置时使用 DefaultConfigLocations；
+// 基础名称可以通过 gospring.config.name 属性修改。不存在的目录会被忽略，直接指定的文件必须存在。
+//
+// 属性的优先级从高到低为：
+//
+//  1. 通过 AddFirst 添加的属性源，如命令行参数
+//  2. SetProperty 设置的属性
+//  3. 环境变量
+//  4. application-<profile> 文件，后激活的配置文件优先
+//  5. application 文件
+//
+// 同一类文件中，靠后的位置优先。基础文件可以设置 gospring.profiles.active 来激活配置文件。
+// 重复调用时同名（同路径）的属性源会被替换。加载的属性源为 *FilePropertySource，
+// 文件修改后可以通过 Refresh 重新加载。
+func (e *StandardEnvironment) LoadConfigFiles(locations ...string) error {
+	if len(locations) == 0 {
+		locations = splitList(e.GetString(ConfigLocationProperty, ""))
+	}
+	if len(locations) == 0 {
+		locations = DefaultConfigLocations
+	}
+	name := e.GetString(ConfigNameProperty, DefaultConfigName)
+
+	base, err := findConfigFiles(locations, name, true)
+	if err != nil {
+		return err
+	}
+	for _, source := range base {
+		e.propertySources.AddLast(source)
+	}
+
+	// 基础文件加载后再确定激活的配置文件
+	profiles := e.GetActiveProfiles()
+	if len(profiles) == 0 {
+		profiles = e.GetDefaultProfiles()
+	}
+
+	var overlays []PropertySource
+	for i := len(profiles) - 1; i >= 0; i-- {
+		sources, err := findConfigFiles(locations, name+"-"+profiles[i], false)
+		if err != nil {
+			return err
+		}
+		overlays = append(overlays, sources...)
+	}
+
+	// 覆盖文件排在基础文件之前
+	for _, source := range base {
+		e.propertySources.Remove(source.Name())
+	}
+	for _, source := range append(overlays, base...) {
+		e.propertySources.AddLast(source)
+	}
+	return nil
+}
+
+// findConfigFiles 在各位置查找指定名称的配置文件，按优先级从高到低返回
+//
+// includeFiles 为 true 时同时加载直接指定为文件的位置。
+func findConfigFiles(locations []string, name string, includeFiles bool) ([]PropertySource, error) {
+	var sources []PropertySource
+	for i := len(locations) - 1; i >= 0; i-- {
+		location := locations[i]
+		if isConfigFile(location) {
+			if !includeFiles {
+				continue
+			}
+			source, err := NewFilePropertySource(location)
+			if err != nil {
+				return nil, err
+			}
+			sources = append(sources, source)
+			continue
+		}
+
+		for _, ext := range configExtensions {
+			path := filepath.Join(location, name+ext)
+			if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			source, err := NewFilePropertySource(path)
+			if err != nil {
+				return nil, err
+			}
+			sources = append(sources, source)
+		}
+	}
+	return sources, nil
+}
+
+// isConfigFile 检查位置是否直接指定了配置文件
+func isConfigFile(location string) bool {
+	return contains(configExtensions, strings.ToLower(filepath.Ext(location)))
+}
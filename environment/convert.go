@@ -0,0 +1,126 @@
+package environment
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Convert 将属性值转换为目标类型
+//
+// 支持字符串、整数、无符号整数、浮点数、布尔值、time.Duration（如 "1m30s"）
+// 和实现 encoding.TextUnmarshaler 的类型（如 time.Time、net.IP）。
+// 切片以逗号分隔元素，例如 "a, b, c"；映射以逗号分隔 key=value 项，例如 "a=1, b=2"。
+func Convert(value string, typ reflect.Type) (reflect.Value, error) {
+	result := reflect.New(typ).Elem()
+	value = strings.TrimSpace(value)
+
+	switch {
+	case reflect.PointerTo(typ).Implements(textUnmarshalerType):
+		if err := result.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value)); err != nil {
+			return reflect.Value{}, err
+		}
+
+	case typ.Kind() == reflect.Ptr:
+		elem, err := Convert(value, typ.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result.Set(reflect.New(typ.Elem()))
+		result.Elem().Set(elem)
+
+	case typ == durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result.SetInt(int64(d))
+
+	case typ.Kind() == reflect.String:
+		result.SetString(value)
+
+	case typ.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result.SetBool(b)
+
+	case typ.Kind() >= reflect.Int && typ.Kind() <= reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, typ.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result.SetInt(i)
+
+	case typ.Kind() >= reflect.Uint && typ.Kind() <= reflect.Uintptr:
+		u, err := strconv.ParseUint(value, 10, typ.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result.SetUint(u)
+
+	case typ.Kind() == reflect.Float32 || typ.Kind() == reflect.Float64:
+		f, err := strconv.ParseFloat(value, typ.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result.SetFloat(f)
+
+	case typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8:
+		result.SetBytes([]byte(value))
+
+	case typ.Kind() == reflect.Slice:
+		items := splitList(value)
+		result.Set(reflect.MakeSlice(typ, 0, len(items)))
+		for _, item := range items {
+			elem, err := Convert(item, typ.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			result.Set(reflect.Append(result, elem))
+		}
+
+	case typ.Kind() == reflect.Map:
+		result.Set(reflect.MakeMap(typ))
+		for _, item := range splitList(value) {
+			k, v, found := strings.Cut(item, "=")
+			if !found {
+				return reflect.Value{}, fmt.Errorf("map entry %q is not in key=value form", item)
+			}
+			key, err := Convert(k, typ.Key())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			elem, err := Convert(v, typ.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			result.SetMapIndex(key, elem)
+		}
+
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported type %v", typ)
+	}
+
+	return result, nil
+}
+
+// splitList 按逗号拆分列表，忽略空白项
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
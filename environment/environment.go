@@ -0,0 +1,239 @@
+package environment
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ActiveProfilesProperty 未通过代码设置激活的配置文件时读取的属性，多个配置文件以逗号分隔
+	ActiveProfilesProperty = "gospring.profiles.active"
+	// ActiveProfilesEnv 以环境变量设置 ActiveProfilesProperty 时使用的名称
+	ActiveProfilesEnv = "GOSPRING_PROFILES_ACTIVE"
+	// DefaultProfile 没有激活任何配置文件时生效的默认配置文件
+	DefaultProfile = "default"
+)
+
+// Environment 应用运行环境，提供配置属性和激活的配置文件
+type Environment interface {
+	// GetProperty 获取属性值，属性不存在时返回 false
+	GetProperty(key string) (string, bool)
+	// GetActiveProfiles 获取激活的配置文件
+	GetActiveProfiles() []string
+	// AcceptsProfiles 检查配置文件表达式中是否有任意一个成立
+	AcceptsProfiles(profiles ...string) bool
+}
+
+// EnvironmentAware 运行环境感知接口，Bean初始化前由应用上下文调用
+type EnvironmentAware interface {
+	SetEnvironment(env Environment)
+}
+
+// StandardEnvironment 由有序属性源组成的运行环境
+//
+// 默认包含两个属性源：SetProperty 写入的 properties 和操作系统环境变量 systemEnvironment，
+// 前者优先。命令行参数、配置文件等属性源通过 GetPropertySources 添加。
+type StandardEnvironment struct {
+	mutex           sync.RWMutex
+	propertySources *MutablePropertySources
+	properties      *MapPropertySource
+	activeProfiles  []string
+	defaultProfiles []string
+	decryptor       PropertyDecryptor
+}
+
+// NewStandardEnvironment 创建包含环境变量属性源的运行环境，默认配置文件为 default
+func NewStandardEnvironment() *StandardEnvironment {
+	env := &StandardEnvironment{
+		propertySources: &MutablePropertySources{},
+		properties:      NewMapPropertySource(PropertiesSourceName, nil),
+		defaultProfiles: []string{DefaultProfile},
+	}
+	env.propertySources.AddLast(env.properties)
+	env.propertySources.AddLast(NewSystemEnvironmentPropertySource())
+	return env
+}
+
+// GetPropertySources 获取可修改的属性源列表
+func (e *StandardEnvironment) GetPropertySources() *MutablePropertySources {
+	return e.propertySources
+}
+
+// GetProperty 按属性源的顺序查找属性值，属性名按宽松规则匹配
+func (e *StandardEnvironment) GetProperty(key string) (string, bool) {
+	for _, source := range e.propertySources.List() {
+		if value, exists := source.GetProperty(key); exists {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// PropertyNames 按字母顺序返回所有属性源中的属性名，同名属性只返回一次
+func (e *StandardEnvironment) PropertyNames() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, source := range e.propertySources.List() {
+		for _, name := range source.PropertyNames() {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetRequiredProperty 获取属性值，属性不存在时返回 *PropertyNotFoundError
+func (e *StandardEnvironment) GetRequiredProperty(key string) (string, error) {
+	value, exists := e.GetProperty(key)
+	if !exists {
+		return "", &PropertyNotFoundError{Key: key}
+	}
+	return value, nil
+}
+
+// ContainsProperty 检查属性是否存在
+func (e *StandardEnvironment) ContainsProperty(key string) bool {
+	_, exists := e.GetProperty(key)
+	return exists
+}
+
+// SetProperty 在优先级最高的 properties 属性源中设置属性值
+//
+// 若 properties 属性源已被移除或替换，写入仍然作用于原属性源。
+func (e *StandardEnvironment) SetProperty(key, value string) {
+	e.properties.Set(key, value)
+}
+
+// GetString 获取字符串属性，属性不存在时返回默认值
+func (e *StandardEnvironment) GetString(key, defaultValue string) string {
+	if value, exists := e.GetProperty(key); exists {
+		return value
+	}
+	return defaultValue
+}
+
+// GetInt 获取整数属性，属性不存在时返回默认值，无法转换时返回 *ConversionError
+func (e *StandardEnvironment) GetInt(key string, defaultValue int) (int, error) {
+	var result int
+	err := e.getConverted(key, defaultValue, &result)
+	return result, err
+}
+
+// GetFloat 获取浮点数属性，属性不存在时返回默认值，无法转换时返回 *ConversionError
+func (e *StandardEnvironment) GetFloat(key string, defaultValue float64) (float64, error) {
+	var result float64
+	err := e.getConverted(key, defaultValue, &result)
+	return result, err
+}
+
+// GetBool 获取布尔属性，属性不存在时返回默认值，无法转换时返回 *ConversionError
+func (e *StandardEnvironment) GetBool(key string, defaultValue bool) (bool, error) {
+	var result bool
+	err := e.getConverted(key, defaultValue, &result)
+	return result, err
+}
+
+// GetDuration 获取时长属性（如 "30s"），属性不存在时返回默认值，无法转换时返回 *ConversionError
+func (e *StandardEnvironment) GetDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	var result time.Duration
+	err := e.getConverted(key, defaultValue, &result)
+	return result, err
+}
+
+// getConverted 获取属性并转换为 target 指向的类型，失败时 target 为默认值
+func (e *StandardEnvironment) getConverted(key string, defaultValue interface{}, target interface{}) error {
+	result := reflect.ValueOf(target).Elem()
+	result.Set(reflect.ValueOf(defaultValue))
+
+	value, exists := e.GetProperty(key)
+	if !exists {
+		return nil
+	}
+	converted, err := Convert(value, result.Type())
+	if err != nil {
+		return &ConversionError{Key: key, Value: MaskValue(e, key, value), TargetType: result.Type(), Cause: err}
+	}
+	result.Set(converted)
+	return nil
+}
+
+// GetActiveProfiles 获取激活的配置文件
+//
+// 未通过 SetActiveProfiles 设置时读取 gospring.profiles.active 属性，
+// 该属性可以来自任意属性源，包括 GOSPRING_PROFILES_ACTIVE 环境变量。
+func (e *StandardEnvironment) GetActiveProfiles() []string {
+	e.mutex.RLock()
+	active := append([]string(nil), e.activeProfiles...)
+	e.mutex.RUnlock()
+
+	if len(active) > 0 {
+		return active
+	}
+	value, _ := e.GetProperty(ActiveProfilesProperty)
+	return splitList(value)
+}
+
+// SetActiveProfiles 设置激活的配置文件，覆盖属性和环境变量中的设置
+func (e *StandardEnvironment) SetActiveProfiles(profiles ...string) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.activeProfiles = splitList(strings.Join(profiles, ","))
+}
+
+// AddActiveProfile 在当前激活的配置文件之外再激活一个配置文件
+func (e *StandardEnvironment) AddActiveProfile(profile string) {
+	profiles := e.GetActiveProfiles()
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.activeProfiles = append(profiles, profile)
+}
+
+// GetDefaultProfiles 获取没有激活任何配置文件时生效的配置文件
+func (e *StandardEnvironment) GetDefaultProfiles() []string {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	return append([]string(nil), e.defaultProfiles...)
+}
+
+// SetDefaultProfiles 设置没有激活任何配置文件时生效的配置文件
+func (e *StandardEnvironment) SetDefaultProfiles(profiles ...string) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.defaultProfiles = splitList(strings.Join(profiles, ","))
+}
+
+// AcceptsProfiles 检查配置文件表达式中是否有任意一个成立
+//
+// 表达式为配置文件名称或以 ! 开头的取反形式，单个参数中可以用逗号分隔多个表达式，
+// 例如 AcceptsProfiles("dev,!prod") 在 dev 激活或 prod 未激活时成立。
+// 没有激活任何配置文件时按默认配置文件判断。
+func (e *StandardEnvironment) AcceptsProfiles(profiles ...string) bool {
+	effective := e.GetActiveProfiles()
+	if len(effective) == 0 {
+		effective = e.GetDefaultProfiles()
+	}
+
+	for _, profile := range splitList(strings.Join(profiles, ",")) {
+		negated := strings.HasPrefix(profile, "!")
+		if contains(effective, strings.TrimPrefix(profile, "!")) != negated {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
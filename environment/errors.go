@@ -0,0 +1,96 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// 用于 errors.Is 判断的错误类别
+var (
+	ErrPropertyNotFound   = errors.New("property not found")
+	ErrPropertyConversion = errors.New("property conversion failed")
+	ErrPropertyBinding    = errors.New("property binding failed")
+)
+
+// PropertyNotFoundError 必需的属性不存在
+type PropertyNotFoundError struct {
+	Key string
+}
+
+func (e *PropertyNotFoundError) Error() string {
+	return fmt.Sprintf("required property '%s' not found", e.Key)
+}
+
+func (e *PropertyNotFoundError) Is(target error) bool {
+	return target == ErrPropertyNotFound
+}
+
+// ConversionError 属性值无法转换为目标类型
+//
+// 敏感属性的 Value 为 MaskedValue，此时错误信息中不包含可能带有原值的 Cause。
+type ConversionError struct {
+	Key        string
+	Value      string
+	TargetType reflect.Type
+	Cause      error
+}
+
+func (e *ConversionError) Error() string {
+	if e.Value == MaskedValue {
+		return fmt.Sprintf("cannot convert property '%s' value %q to %v", e.Key, e.Value, e.TargetType)
+	}
+	return fmt.Sprintf("cannot convert property '%s' value %q to %v: %v", e.Key, e.Value, e.TargetType, e.Cause)
+}
+
+func (e *ConversionError) Is(target error) bool {
+	return target == ErrPropertyConversion
+}
+
+func (e *ConversionError) Unwrap() error {
+	return e.Cause
+}
+
+// InvalidPropertyError 绑定配置属性时无法转换或校验失败的属性
+type InvalidPropertyError struct {
+	Key    string // 属性名
+	Value  string // 属性值，属性不存在时为字段的当前值
+	Target string // 绑定的目标字段，例如 config.ServerConfig.Timeouts.Read
+	Cause  error
+}
+
+func (e *InvalidPropertyError) Error() string {
+	return fmt.Sprintf("property '%s' with value %q bound to %s: %v", e.Key, e.Value, e.Target, e.Cause)
+}
+
+func (e *InvalidPropertyError) Unwrap() error {
+	return e.Cause
+}
+
+// BindingError 配置属性绑定失败，汇总所有无效属性
+type BindingError struct {
+	Errors []*InvalidPropertyError
+}
+
+func (e *BindingError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "failed to bind %d configuration properties:", len(e.Errors))
+	for _, err := range e.Errors {
+		sb.WriteString("\n  - ")
+		sb.WriteString(err.Error())
+	}
+	return sb.String()
+}
+
+func (e *BindingError) Is(target error) bool {
+	return target == ErrPropertyBinding
+}
+
+func (e *BindingError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
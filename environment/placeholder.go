@@ -0,0 +1,156 @@
+package environment
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	placeholderPrefix = "${"
+	placeholderSuffix = "}"
+	valueSeparator    = ":"
+)
+
+// ResolvePlaceholders 替换文本中 ${key} 和 ${key:default} 形式的占位符
+//
+// 属性不存在时使用默认值，没有默认值时返回 *PropertyNotFoundError。
+// 占位符可以嵌套，例如 ${app.url:http://${server.host:localhost}:${server.port:8080}}；
+// 属性值中的占位符同样会被解析，循环引用时返回错误。
+//
+// ${file:path} 替换为文件的内容（去掉末尾的换行），用于读取挂载的密钥文件；
+// ENC(...) 形式的属性值由运行环境的 PropertyDecryptor 解密，解密后的值不再解析占位符。
+func ResolvePlaceholders(env Environment, text string) (string, error) {
+	return resolvePlaceholders(env, text, nil)
+}
+
+// ResolvePlaceholders 替换文本中的占位符，见 ResolvePlaceholders 函数
+func (e *StandardEnvironment) ResolvePlaceholders(text string) (string, error) {
+	return resolvePlaceholders(e, text, nil)
+}
+
+// resolvePlaceholders 解析占位符，visiting 为正在解析的属性，用于检测循环引用
+func resolvePlaceholders(env Environment, text string, visiting []string) (string, error) {
+	var sb strings.Builder
+	for {
+		start := strings.Index(text, placeholderPrefix)
+		if start < 0 {
+			break
+		}
+		end := placeholderEnd(text, start)
+		if end < 0 {
+			break
+		}
+		sb.WriteString(text[:start])
+		content := text[start+len(placeholderPrefix) : end]
+
+		if path, ok := strings.CutPrefix(content, filePrefix); ok {
+			path, err := resolvePlaceholders(env, path, visiting)
+			if err != nil {
+				return "", err
+			}
+			value, err := readSecretFile(path)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(value)
+			text = text[end+len(placeholderSuffix):]
+			continue
+		}
+
+		key, defaultValue, hasDefault := splitPlaceholder(content)
+		key, err := resolvePlaceholders(env, key, visiting)
+		if err != nil {
+			return "", err
+		}
+		if contains(visiting, key) {
+			return "", fmt.Errorf("circular placeholder reference '%s' in property value", key)
+		}
+
+		value, exists := env.GetProperty(key)
+		switch {
+		case exists:
+			value, err = resolveValue(env, key, value, visiting)
+		case hasDefault:
+			value, err = resolvePlaceholders(env, defaultValue, visiting)
+		default:
+			err = &PropertyNotFoundError{Key: key}
+		}
+		if err != nil {
+			return "", err
+		}
+
+		sb.WriteString(value)
+		text = text[end+len(placeholderSuffix):]
+	}
+	sb.WriteString(text)
+	return sb.String(), nil
+}
+
+// ResolveProperty 获取属性值，解密 ENC(...) 密文并解析其中的占位符，见 ResolvePlaceholders
+func ResolveProperty(env Environment, key string) (string, bool, error) {
+	value, exists := env.GetProperty(key)
+	if !exists {
+		return "", false, nil
+	}
+	value, err := resolveValue(env, key, value, nil)
+	return value, true, err
+}
+
+// ResolveProperty 获取解密并解析占位符后的属性值，见 ResolveProperty 函数
+func (e *StandardEnvironment) ResolveProperty(key string) (string, bool, error) {
+	return ResolveProperty(e, key)
+}
+
+// resolveValue 解析属性 key 的值，密文解密后直接返回
+func resolveValue(env Environment, key, value string, visiting []string) (string, error) {
+	if IsEncrypted(value) {
+		return decrypt(env, key, value)
+	}
+	return resolvePlaceholders(env, value, append(visiting[:len(visiting):len(visiting)], key))
+}
+
+// placeholderEnd 查找与 start 处占位符匹配的结束位置，未闭合时返回 -1
+func placeholderEnd(text string, start int) int {
+	depth := 0
+	for i := start + len(placeholderPrefix); i < len(text); i++ {
+		switch {
+		case strings.HasPrefix(text[i:], placeholderPrefix):
+			depth++
+			i += len(placeholderPrefix) - 1
+		case strings.HasPrefix(text[i:], placeholderSuffix):
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// splitPlaceholder 在嵌套占位符之外的第一个 : 处拆分属性名和默认值
+func splitPlaceholder(content string) (key, defaultValue string, hasDefault bool) {
+	depth := 0
+	for i := 0; i < len(content); i++ {
+		switch {
+		case strings.HasPrefix(content[i:], placeholderPrefix):
+			depth++
+			i += len(placeholderPrefix) - 1
+		case strings.HasPrefix(content[i:], placeholderSuffix):
+			depth--
+		case depth == 0 && strings.HasPrefix(content[i:], valueSeparator):
+			return content[:i], content[i+len(valueSeparator):], true
+		}
+	}
+	return content, "", false
+}
+
+// PlaceholderKey 返回只由一个占位符组成的表达式中的属性名，其他表达式和 ${file:...} 返回空字符串
+func PlaceholderKey(expression string) string {
+	expression = strings.TrimSpace(expression)
+	if !strings.HasPrefix(expression, placeholderPrefix) || placeholderEnd(expression, 0) != len(expression)-len(placeholderSuffix) ||
+		strings.HasPrefix(expression, placeholderPrefix+filePrefix) {
+		return ""
+	}
+	key, _, _ := splitPlaceholder(expression[len(placeholderPrefix) : len(expression)-len(placeholderSuffix)])
+	return key
+}
@@ -0,0 +1,313 @@
+package environment
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 内置属性源的名称
+const (
+	PropertiesSourceName        = "properties"        // SetProperty 写入的属性
+	SystemEnvironmentSourceName = "systemEnvironment" // 操作系统环境变量
+	CommandLineSourceName       = "commandLineArgs"   // 命令行参数
+)
+
+// PropertySource 属性源，Environment 按顺序在属性源中查找属性
+type PropertySource interface {
+	// Name 属性源名称，在同一环境中唯一
+	Name() string
+	// GetProperty 获取属性值，属性不存在时返回 false
+	GetProperty(key string) (string, bool)
+	// PropertyNames 返回属性源中的所有属性名
+	PropertyNames() []string
+}
+
+// canonicalKey 宽松匹配使用的规范形式：忽略大小写和 - ，_ 视为 .
+//
+// server.port、SERVER_PORT 和 Server.Port 互相匹配，server.max-connections、
+// server.maxConnections 和 SERVER_MAXCONNECTIONS 互相匹配。
+func canonicalKey(key string) string {
+	return strings.ToLower(strings.NewReplacer("_", ".", "-", "").Replace(key))
+}
+
+// MapPropertySource 基于映射的属性源，按宽松规则匹配属性名
+type MapPropertySource struct {
+	name       string
+	mutex      sync.RWMutex
+	properties map[string]string
+	relaxed    map[string]string // 规范形式到属性名的映射
+}
+
+// NewMapPropertySource 以映射创建属性源，映射会被复制
+func NewMapPropertySource(name string, properties map[string]string) *MapPropertySource {
+	source := &MapPropertySource{
+		name:       name,
+		properties: make(map[string]string, len(properties)),
+		relaxed:    make(map[string]string, len(properties)),
+	}
+	for key, value := range properties {
+		source.Set(key, value)
+	}
+	return source
+}
+
+// Name 属性源名称
+func (s *MapPropertySource) Name() string {
+	return s.name
+}
+
+// GetProperty 获取属性值，找不到同名属性时按宽松规则匹配
+func (s *MapPropertySource) GetProperty(key string) (string, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if value, exists := s.properties[key]; exists {
+		return value, true
+	}
+	if name, exists := s.relaxed[canonicalKey(key)]; exists {
+		return s.properties[name], true
+	}
+	return "", false
+}
+
+// PropertyNames 按字母顺序返回所有属性名
+func (s *MapPropertySource) PropertyNames() []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	names := make([]string, 0, len(s.properties))
+	for name := range s.properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Set 设置属性值
+func (s *MapPropertySource) Set(key, value string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.properties[key] = value
+	s.relaxed[canonicalKey(key)] = key
+}
+
+// Remove 删除属性
+func (s *MapPropertySource) Remove(key string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.properties, key)
+	delete(s.relaxed, canonicalKey(key))
+}
+
+// SystemEnvironmentPropertySource 操作系统环境变量属性源，每次查找读取当前的环境变量
+//
+// server.port 依次查找 server.port 和 SERVER_PORT，- 会被去掉，
+// 因此 server.max-connections 对应 SERVER_MAXCONNECTIONS。
+type SystemEnvironmentPropertySource struct{}
+
+// NewSystemEnvironmentPropertySource 创建环境变量属性源
+func NewSystemEnvironmentPropertySource() *SystemEnvironmentPropertySource {
+	return &SystemEnvironmentPropertySource{}
+}
+
+// Name 属性源名称
+func (s *SystemEnvironmentPropertySource) Name() string {
+	return SystemEnvironmentSourceName
+}
+
+// GetProperty 获取环境变量
+func (s *SystemEnvironmentPropertySource) GetProperty(key string) (string, bool) {
+	if value, exists := os.LookupEnv(key); exists {
+		return value, true
+	}
+	return os.LookupEnv(strings.ToUpper(strings.NewReplacer(".", "_", "-", "").Replace(key)))
+}
+
+// PropertyNames 返回所有环境变量名
+func (s *SystemEnvironmentPropertySource) PropertyNames() []string {
+	var names []string
+	for _, entry := range os.Environ() {
+		if i := strings.Index(entry, "="); i > 0 {
+			names = append(names, entry[:i])
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CommandLinePropertySource 命令行参数属性源
+//
+// --key=value 设置属性，--flag 设置为 "true"，其余参数可通过 NonOptionArgs 获取。
+// 单独的 -- 之后的参数都视为非选项参数。
+type CommandLinePropertySource struct {
+	*MapPropertySource
+	nonOptionArgs []string
+}
+
+// NewCommandLinePropertySource 解析命令行参数，args 不包括程序名
+func NewCommandLinePropertySource(args []string) *CommandLinePropertySource {
+	source := &CommandLinePropertySource{
+		MapPropertySource: NewMapPropertySource(CommandLineSourceName, nil),
+	}
+	for i, arg := range args {
+		if arg == "--" {
+			source.nonOptionArgs = append(source.nonOptionArgs, args[i+1:]...)
+			break
+		}
+		if !strings.HasPrefix(arg, "--") || len(arg) == 2 {
+			source.nonOptionArgs = append(source.nonOptionArgs, arg)
+			continue
+		}
+		key, value, hasValue := strings.Cut(arg[2:], "=")
+		if !hasValue {
+			value = "true"
+		}
+		source.Set(key, value)
+	}
+	return source
+}
+
+// NonOptionArgs 返回不是 --key=value 形式的参数
+func (s *CommandLinePropertySource) NonOptionArgs() []string {
+	return append([]string(nil), s.nonOptionArgs...)
+}
+
+// LoadPropertiesFile 读取 key=value 格式的属性文件
+//
+// 每行一个属性，分隔符可以是 = 或 :，以 # 或 ! 开头的行为注释。属性源以文件路径命名。
+func LoadPropertiesFile(path string) (*MapPropertySource, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	source := NewMapPropertySource(path, nil)
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		i := strings.IndexAny(line, "=:")
+		if i <= 0 {
+			return nil, fmt.Errorf("%s:%d: expected key=value, got %q", path, lineNumber, line)
+		}
+		source.Set(strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:]))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return source, nil
+}
+
+// MutablePropertySources 有序的属性源列表，排在前面的属性源优先
+type MutablePropertySources struct {
+	mutex   sync.RWMutex
+	sources []PropertySource
+}
+
+// AddFirst 以最高优先级添加属性源，同名的属性源会先被移除
+func (m *MutablePropertySources) AddFirst(source PropertySource) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.remove(source.Name())
+	m.sources = append([]PropertySource{source}, m.sources...)
+}
+
+// AddLast 以最低优先级添加属性源，同名的属性源会先被移除
+func (m *MutablePropertySources) AddLast(source PropertySource) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.remove(source.Name())
+	m.sources = append(m.sources, source)
+}
+
+// AddBefore 将属性源添加到名为 relative 的属性源之前
+func (m *MutablePropertySources) AddBefore(relative string, source PropertySource) error {
+	return m.addRelative(relative, source, 0)
+}
+
+// AddAfter 将属性源添加到名为 relative 的属性源之后
+func (m *MutablePropertySources) AddAfter(relative string, source PropertySource) error {
+	return m.addRelative(relative, source, 1)
+}
+
+func (m *MutablePropertySources) addRelative(relative string, source PropertySource, offset int) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if relative == source.Name() {
+		return fmt.Errorf("property source '%s' cannot be positioned relative to itself", relative)
+	}
+	if m.indexOf(relative) < 0 {
+		return fmt.Errorf("property source '%s' does not exist", relative)
+	}
+	m.remove(source.Name())
+	i := m.indexOf(relative) + offset
+	m.sources = append(m.sources[:i], append([]PropertySource{source}, m.sources[i:]...)...)
+	return nil
+}
+
+// Replace 以同样的优先级替换名为 name 的属性源
+func (m *MutablePropertySources) Replace(name string, source PropertySource) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	i := m.indexOf(name)
+	if i < 0 {
+		return fmt.Errorf("property source '%s' does not exist", name)
+	}
+	m.sources[i] = source
+	return nil
+}
+
+// Remove 移除属性源并返回它，不存在时返回nil
+func (m *MutablePropertySources) Remove(name string) PropertySource {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.remove(name)
+}
+
+// Get 按名称获取属性源，不存在时返回nil
+func (m *MutablePropertySources) Get(name string) PropertySource {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if i := m.indexOf(name); i >= 0 {
+		return m.sources[i]
+	}
+	return nil
+}
+
+// List 按优先级从高到低返回所有属性源
+func (m *MutablePropertySources) List() []PropertySource {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return append([]PropertySource(nil), m.sources...)
+}
+
+// remove 移除属性源，调用方需持有写锁
+func (m *MutablePropertySources) remove(name string) PropertySource {
+	i := m.indexOf(name)
+	if i < 0 {
+		return nil
+	}
+	source := m.sources[i]
+	m.sources = append(m.sources[:i], m.sources[i+1:]...)
+	return source
+}
+
+// indexOf 查找属性源的位置，调用方需持有锁
+func (m *MutablePropertySources) indexOf(name string) int {
+	for i, source := range m.sources {
+		if source.Name() == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,162 @@
+package environment
+
+import (
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ReloadablePropertySource 可以重新加载的属性源，由 StandardEnvironment.Refresh 检查
+type ReloadablePropertySource interface {
+	PropertySource
+	// Reload 检查并重新加载属性，返回值有变化的属性名，没有变化时返回空
+	Reload() ([]string, error)
+}
+
+// ConfigChanged 属性源重新加载后的变化
+type ConfigChanged struct {
+	Sources []string // 发生变化的属性源名称
+	Keys    []string // 新增、删除或值有变化的属性名，按字母顺序排列
+}
+
+// RefreshListener 配置变化监听接口，配置对象重新绑定后由应用上下文按初始化顺序调用
+type RefreshListener interface {
+	OnConfigChanged(event *ConfigChanged)
+}
+
+// FilePropertySource 从配置文件加载的属性源，文件的修改时间或大小变化时 Reload 重新读取
+//
+// 格式由扩展名决定，见 LoadConfigFile。属性源以文件路径命名。
+type FilePropertySource struct {
+	path    string
+	mutex   sync.RWMutex
+	source  *MapPropertySource
+	modTime time.Time
+	size    int64
+}
+
+// NewFilePropertySource 读取配置文件并创建属性源
+func NewFilePropertySource(path string) (*FilePropertySource, error) {
+	s := &FilePropertySource{path: path}
+	if _, err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Name 属性源名称，即文件路径
+func (s *FilePropertySource) Name() string {
+	return s.path
+}
+
+// GetProperty 获取属性值，属性名按宽松规则匹配
+func (s *FilePropertySource) GetProperty(key string) (string, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.source.GetProperty(key)
+}
+
+// PropertyNames 按字母顺序返回所有属性名
+func (s *FilePropertySource) PropertyNames() []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.source.PropertyNames()
+}
+
+// Reload 文件的修改时间或大小变化时重新读取，读取失败时保持原有属性
+func (s *FilePropertySource) Reload() ([]string, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.RLock()
+	unchanged := info.ModTime().Equal(s.modTime) && info.Size() == s.size
+	s.mutex.RUnlock()
+	if unchanged {
+		return nil, nil
+	}
+	return s.load()
+}
+
+// load 读取文件并替换属性，返回值有变化的属性名
+func (s *FilePropertySource) load() ([]string, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, err
+	}
+	source, err := LoadConfigFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var changed []string
+	if s.source != nil {
+		changed = diffProperties(s.source, source)
+	}
+	s.source = source
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	return changed, nil
+}
+
+// diffProperties 比较两个属性源，返回新增、删除或值有变化的属性名
+func diffProperties(previous, current PropertySource) []string {
+	var changed []string
+	for _, name := range previous.PropertyNames() {
+		before, _ := previous.GetProperty(name)
+		if after, exists := current.GetProperty(name); !exists || after != before {
+			changed = append(changed, name)
+		}
+	}
+	for _, name := range current.PropertyNames() {
+		if _, exists := previous.GetProperty(name); !exists {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// Refresh 重新加载所有可重新加载的属性源，返回属性的变化，没有变化时返回nil
+//
+// 某个属性源加载失败时保持其原有属性并继续检查其他属性源，返回第一个错误。
+func (e *StandardEnvironment) Refresh() (*ConfigChanged, error) {
+	var event *ConfigChanged
+	var firstErr error
+	seen := make(map[string]bool)
+
+	for _, source := range e.propertySources.List() {
+		reloadable, ok := source.(ReloadablePropertySource)
+		if !ok {
+			continue
+		}
+		changed, err := reloadable.Reload()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		if len(changed) == 0 {
+			continue
+		}
+
+		if event == nil {
+			event = &ConfigChanged{}
+		}
+		event.Sources = append(event.Sources, source.Name())
+		for _, key := range changed {
+			if !seen[key] {
+				seen[key] = true
+				event.Keys = append(event.Keys, key)
+			}
+		}
+	}
+
+	if event != nil {
+		sort.Strings(event.Keys)
+	}
+	return event, firstErr
+}
@@ -0,0 +1,203 @@
+package environment
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// EncryptionKeyEnv NewAESGCMDecryptorFromEnv 默认读取的环境变量，值为 base64 编码的 16、24 或 32 字节密钥
+	EncryptionKeyEnv = "GOSPRING_ENCRYPTION_KEY"
+	// MaskedValue 日志和诊断输出中代替敏感属性值的文本
+	MaskedValue = "******"
+
+	encryptedPrefix = "ENC("
+	encryptedSuffix = ")"
+	filePrefix      = "file:"
+)
+
+// SecretKeywords 属性名中包含这些关键字（忽略大小写和分隔符）时视为敏感属性
+var SecretKeywords = []string{"password", "passwd", "secret", "token", "credential", "privatekey", "apikey"}
+
+// PropertyDecryptor 属性解密接口，用于解密 ENC(...) 形式的属性值
+//
+// 注册到应用上下文的实现会在绑定配置和注入属性之前设置到运行环境中，
+// 因此解密器本身不应依赖其他Bean。
+type PropertyDecryptor interface {
+	// Decrypt 解密 ENC(...) 括号中的密文
+	Decrypt(ciphertext string) (string, error)
+}
+
+// SetDecryptor 设置解密 ENC(...) 属性值使用的解密器，传入 nil 时取消
+func (e *StandardEnvironment) SetDecryptor(decryptor PropertyDecryptor) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.decryptor = decryptor
+}
+
+// GetDecryptor 获取解密器，未设置时返回nil
+func (e *StandardEnvironment) GetDecryptor() PropertyDecryptor {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	return e.decryptor
+}
+
+// IsEncrypted 检查属性值是否为 ENC(...) 形式的密文
+func IsEncrypted(value string) bool {
+	value = strings.TrimSpace(value)
+	return strings.HasPrefix(value, encryptedPrefix) && strings.HasSuffix(value, encryptedSuffix)
+}
+
+// decrypt 使用运行环境的解密器解密属性值，不是密文时原样返回
+func decrypt(env Environment, key, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	var decryptor PropertyDecryptor
+	if aware, ok := env.(interface{ GetDecryptor() PropertyDecryptor }); ok {
+		decryptor = aware.GetDecryptor()
+	}
+	if decryptor == nil {
+		return "", fmt.Errorf("property '%s' is encrypted but no PropertyDecryptor is configured", key)
+	}
+
+	value = strings.TrimSpace(value)
+	plaintext, err := decryptor.Decrypt(value[len(encryptedPrefix) : len(value)-len(encryptedSuffix)])
+	if err != nil {
+		return "", fmt.Errorf("cannot decrypt property '%s': %w", key, err)
+	}
+	return plaintext, nil
+}
+
+// readSecretFile 读取 ${file:path} 引用的文件，去掉末尾的换行
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(strings.TrimSpace(path))
+	if err != nil {
+		return "", fmt.Errorf("cannot read property file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// IsSecretKey 检查属性名是否包含 SecretKeywords 中的关键字
+func IsSecretKey(key string) bool {
+	normalized := strings.NewReplacer(".", "", "-", "", "_", "").Replace(strings.ToLower(key))
+	for _, keyword := range SecretKeywords {
+		if strings.Contains(normalized, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaskValue 返回可以写入日志和错误信息的属性值
+//
+// 属性名是敏感属性名，或属性的原始值为 ENC(...) 密文或引用了 ${file:...} 文件时返回 MaskedValue，
+// 否则原样返回 value。
+func MaskValue(env Environment, key, value string) string {
+	if IsSecretKey(key) {
+		return MaskedValue
+	}
+	if env != nil {
+		if raw, exists := env.GetProperty(key); exists && IsSecretValue(raw) {
+			return MaskedValue
+		}
+	}
+	if IsSecretValue(value) {
+		return MaskedValue
+	}
+	return value
+}
+
+// IsSecretValue 检查原始属性值或表达式是否为 ENC(...) 密文或引用了 ${file:...} 文件
+func IsSecretValue(raw string) bool {
+	return IsEncrypted(raw) || strings.Contains(raw, placeholderPrefix+filePrefix)
+}
+
+// Dump 按优先级列出所有属性源及其属性，用于诊断，敏感属性的值显示为 MaskedValue
+func (e *StandardEnvironment) Dump() string {
+	var sb strings.Builder
+	for _, source := range e.propertySources.List() {
+		fmt.Fprintf(&sb, "%s:\n", source.Name())
+		named, ok := source.(interface{ PropertyNames() []string })
+		if !ok {
+			continue
+		}
+		for _, name := range named.PropertyNames() {
+			value, _ := source.GetProperty(name)
+			if IsSecretKey(name) || IsSecretValue(value) {
+				value = MaskedValue
+			}
+			fmt.Fprintf(&sb, "  %s=%s\n", name, value)
+		}
+	}
+	return sb.String()
+}
+
+// AESGCMDecryptor 使用 AES-GCM 解密属性值
+//
+// 密文为 base64 编码的 12 字节随机数与加密结果的拼接，可以由 Encrypt 生成。
+type AESGCMDecryptor struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCMDecryptor 使用 16、24 或 32 字节的密钥创建解密器，分别对应 AES-128、AES-192 和 AES-256
+func NewAESGCMDecryptor(key []byte) (*AESGCMDecryptor, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &AESGCMDecryptor{aead: aead}, nil
+}
+
+// NewAESGCMDecryptorFromEnv 从环境变量读取 base64 编码的密钥并创建解密器，name 为空时使用 EncryptionKeyEnv
+func NewAESGCMDecryptorFromEnv(name string) (*AESGCMDecryptor, error) {
+	if name == "" {
+		name = EncryptionKeyEnv
+	}
+	encoded, exists := os.LookupEnv(name)
+	if !exists || encoded == "" {
+		return nil, fmt.Errorf("encryption key environment variable %s is not set", name)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("encryption key environment variable %s: %w", name, err)
+	}
+	return NewAESGCMDecryptor(key)
+}
+
+// Encrypt 加密明文，返回可以写在 ENC(...) 中的密文
+func (d *AESGCMDecryptor) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, d.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := d.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt 解密 Encrypt 生成的密文
+func (d *AESGCMDecryptor) Decrypt(ciphertext string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(ciphertext))
+	if err != nil {
+		return "", err
+	}
+	if len(data) < d.aead.NonceSize() {
+		return "", fmt.Errorf("ciphertext too short")
+	}
+	nonce, sealed := data[:d.aead.NonceSize()], data[d.aead.NonceSize():]
+	plaintext, err := d.aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
@@ -5,8 +5,8 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"reflect"
 	"strconv"
+	"gospring/container"
 	"gospring/context"
 )
 
@@ -279,11 +279,12 @@ func main() {
 	}
 	
 	// 通过接口注册服务
-	productServiceType := reflect.TypeOf((*ProductService)(nil)).Elem()
-	orderServiceType := reflect.TypeOf((*OrderService)(nil)).Elem()
-	
-	ctx.RegisterByInterface(productServiceType, productService, "productServiceInterface")
-	ctx.RegisterByInterface(orderServiceType, orderService, "orderServiceInterface")
+	if err := container.Bind[ProductService](ctx, "productServiceInterface", productService); err != nil {
+		log.Fatalf("绑定接口失败: %v", err)
+	}
+	if err := container.Bind[OrderService](ctx, "orderServiceInterface", orderService); err != nil {
+		log.Fatalf("绑定接口失败: %v", err)
+	}
 	
 	// 启动上下文
 	fmt.Println("2. 启动应用上下文...")
@@ -295,8 +296,8 @@ func main() {
 	fmt.Println("3. 设置HTTP路由...")
 	
 	// 获取控制器并设置路由
-	prodCtrl := ctx.GetBean("productController").(*ProductController)
-	orderCtrl := ctx.GetBean("orderController").(*OrderController)
+	prodCtrl := container.MustResolve[*ProductController](ctx)
+	orderCtrl := container.MustResolve[*OrderController](ctx)
 	
 	prodCtrl.SetupRoutes()
 	orderCtrl.SetupRoutes()
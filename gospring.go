@@ -0,0 +1,271 @@
+// Package gospring 提供命令行应用的启动入口
+//
+// Run 解析命令行参数、加载配置文件、注册组件并启动应用上下文，
+// 依次调用运行器Bean后阻塞到收到关闭信号：
+//
+//	func main() {
+//		gospring.Run(os.Args[1:], &UserRepository{}, &UserService{}, &ImportJob{})
+//	}
+package gospring
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+
+	"gospring/context"
+	"gospring/environment"
+)
+
+const (
+	// ApplicationArgumentsBeanName 命令行参数 *ApplicationArguments 注册的Bean名称
+	ApplicationArgumentsBeanName = "applicationArguments"
+	// KeepAliveProperty 运行器执行完后是否等待关闭信号，默认为 true；设置为 false 时执行完即停止
+	KeepAliveProperty = "gospring.main.keep-alive"
+)
+
+// 进程的退出码
+const (
+	ExitOK              = 0
+	ExitStartupFailure  = 1 // 配置加载、组件注册或上下文启动失败
+	ExitRunnerFailure   = 2 // 运行器返回错误
+	ExitShutdownFailure = 3 // 停止上下文时有Bean销毁失败或超时
+)
+
+// CommandLineRunner 上下文启动后调用的运行器，参数为原始的命令行参数
+type CommandLineRunner interface {
+	Run(args []string) error
+}
+
+// ApplicationRunner 上下文启动后调用的运行器，参数为解析后的命令行参数
+type ApplicationRunner interface {
+	Run(args *ApplicationArguments) error
+}
+
+// ExitCoder 运行器返回的错误实现该接口时，以其退出码结束进程
+type ExitCoder interface {
+	ExitCode() int
+}
+
+// ExitError 应用运行失败，Code 为默认的退出码
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode 退出码，Err 实现了 ExitCoder 时优先使用其退出码
+func (e *ExitError) ExitCode() int {
+	var coder ExitCoder
+	if errors.As(e.Err, &coder) {
+		return coder.ExitCode()
+	}
+	return e.Code
+}
+
+// ExitCode 返回错误对应的退出码，nil 为 ExitOK，没有指定退出码的错误为 ExitStartupFailure
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var coder ExitCoder
+	if errors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+	return ExitStartupFailure
+}
+
+// ApplicationArguments 解析后的命令行参数，以 applicationArguments 注册为Bean
+//
+// --key=value 为选项，--flag 的值为 "true"，其余参数为非选项参数，规则见 environment.CommandLinePropertySource。
+type ApplicationArguments struct {
+	args   []string
+	source *environment.CommandLinePropertySource
+}
+
+// NewApplicationArguments 解析命令行参数，args 不包括程序名
+func NewApplicationArguments(args []string) *ApplicationArguments {
+	return &ApplicationArguments{
+		args:   append([]string(nil), args...),
+		source: environment.NewCommandLinePropertySource(args),
+	}
+}
+
+// SourceArgs 返回原始的命令行参数
+func (a *ApplicationArguments) SourceArgs() []string {
+	return append([]string(nil), a.args...)
+}
+
+// NonOptionArgs 返回非选项参数
+func (a *ApplicationArguments) NonOptionArgs() []string {
+	return a.source.NonOptionArgs()
+}
+
+// OptionNames 按字母顺序返回选项名称
+func (a *ApplicationArguments) OptionNames() []string {
+	return a.source.PropertyNames()
+}
+
+// ContainsOption 检查是否指定了选项
+func (a *ApplicationArguments) ContainsOption(name string) bool {
+	_, exists := a.source.GetProperty(name)
+	return exists
+}
+
+// OptionValue 获取选项的值，选项不存在时返回 false
+func (a *ApplicationArguments) OptionValue(name string) (string, bool) {
+	return a.source.GetProperty(name)
+}
+
+// Application 命令行应用，封装应用上下文的启动、运行器调用和停止
+type Application struct {
+	ctx          *context.ApplicationContext
+	components   []interface{}
+	signals      []os.Signal
+	shutdown     chan struct{}
+	shutdownOnce sync.Once
+}
+
+// NewApplication 创建命令行应用，组件在 Run 加载配置文件之后注册
+func NewApplication(components ...interface{}) *Application {
+	return &Application{
+		ctx:        context.NewApplicationContext(),
+		components: components,
+		shutdown:   make(chan struct{}),
+	}
+}
+
+// Context 获取应用上下文，可以在 Run 之前设置日志器或注册其他Bean
+func (app *Application) Context() *context.ApplicationContext {
+	return app.ctx
+}
+
+// SetSignals 设置关闭钩子监听的信号，默认为 SIGINT 和 SIGTERM
+func (app *Application) SetSignals(signals ...os.Signal) {
+	app.signals = signals
+}
+
+// Shutdown 结束 Run 对关闭信号的等待，可以在任意协程中调用
+func (app *Application) Shutdown() {
+	app.shutdownOnce.Do(func() {
+		close(app.shutdown)
+	})
+}
+
+// Run 启动应用，阻塞到收到关闭信号或调用 Shutdown 后停止上下文
+//
+// 依次执行以下步骤，失败时返回带有退出码的 *ExitError：
+//
+//  2. 注册 *ApplicationArguments 和组件，启动应用上下文；启动中途失败时已经初始化的Bean被销毁，销毁的错误一并返回
+//  2. 注册 *ApplicationArguments 和组件，启动应用上下文
+//  3. 按排序值调用实现 CommandLineRunner 或 ApplicationRunner 的Bean，任一失败时停止上下文，停止的错误一并返回
+//  4. 通过 RegisterShutdownHook 等待关闭信号后停止上下文；gospring.main.keep-alive 为 false 时直接停止
+func (app *Application) Run(args []string) error {
+	arguments := NewApplicationArguments(args)
+	env := app.ctx.GetEnvironment()
+	env.GetPropertySources().AddFirst(arguments.source)
+
+	if err := env.LoadConfigFiles(); err != nil {
+		return &ExitError{Code: ExitStartupFailure, Err: fmt.Errorf("failed to load config files: %w", err)}
+	}
+	if err := app.ctx.RegisterSingleton(ApplicationArgumentsBeanName, arguments); err != nil {
+		return &ExitError{Code: ExitStartupFailure, Err: err}
+	}
+	if err := app.ctx.RegisterComponents(app.components...); err != nil {
+		return &ExitError{Code: ExitStartupFailure, Err: fmt.Errorf("failed to register components: %w", err)}
+	}
+	if err := app.ctx.Start(); err != nil {
+		return &ExitError{Code: ExitStartupFailure, Err: err}
+	}
+
+	if err := app.callRunners(arguments); err != nil {
+		return &ExitError{Code: ExitRunnerFailure, Err: errors.Join(err, app.ctx.Stop())}
+	}
+
+	keepAlive, err := env.GetBool(KeepAliveProperty, true)
+	if err != nil {
+		return &ExitError{Code: ExitStartupFailure, Err: errors.Join(err, app.ctx.Stop())}
+	}
+
+	if keepAlive {
+		err = app.await()
+	} else {
+		err = app.ctx.Stop()
+	}
+	if err != nil {
+		return &ExitError{Code: ExitShutdownFailure, Err: err}
+	}
+	return nil
+}
+
+// callRunners 按排序值调用运行器，排序值相同时按注册顺序
+func (app *Application) callRunners(arguments *ApplicationArguments) error {
+	commandLineRunners := app.beanNameSet(reflect.TypeOf((*CommandLineRunner)(nil)).Elem())
+	applicationRunners := app.beanNameSet(reflect.TypeOf((*ApplicationRunner)(nil)).Elem())
+
+	for _, name := range app.ctx.GetBeanNamesForType(reflect.TypeOf((*interface{})(nil)).Elem()) {
+		if !commandLineRunners[name] && !applicationRunners[name] {
+			continue
+		}
+		bean, err := app.ctx.LookupBean(name)
+		if err != nil {
+			return err
+		}
+
+		switch runner := bean.(type) {
+		case CommandLineRunner:
+			err = runner.Run(arguments.SourceArgs())
+		case ApplicationRunner:
+			err = runner.Run(arguments)
+		}
+		if err != nil {
+			return fmt.Errorf("runner '%s' failed: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (app *Application) beanNameSet(typ reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for _, name := range app.ctx.GetBeanNamesForType(typ) {
+		names[name] = true
+	}
+	return names
+}
+
+// await 阻塞到关闭钩子收到信号或调用 Shutdown，返回停止上下文的结果
+func (app *Application) await() error {
+	stopped := app.ctx.RegisterShutdownHook(app.signals...)
+	select {
+	case err := <-stopped:
+		return err
+	case <-app.shutdown:
+	}
+
+	err := app.ctx.Stop()
+	if errors.Is(err, context.ErrNotStarted) {
+		// 关闭钩子同时收到信号，已经停止上下文
+		return <-stopped
+	}
+	return err
+}
+
+// Run 使用命令行参数（不包括程序名）运行应用，结束后以对应的退出码退出进程
+//
+// 失败时将错误写入标准错误输出，退出码见 ExitCode。
+func Run(args []string, components ...interface{}) {
+	err := NewApplication(components...).Run(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "application failed: %v\n", err)
+	}
+	os.Exit(ExitCode(err))
+}
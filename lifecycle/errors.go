@@ -0,0 +1,64 @@
+package lifecycle
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrLifecycle 用于 errors.Is 判断生命周期回调失败
+var ErrLifecycle = errors.New("lifecycle callback failed")
+
+// Phase 生命周期阶段
+type Phase string
+
+const (
+	PhaseInit    Phase = "init"
+	PhaseDestroy Phase = "destroy"
+)
+
+// 生命周期回调方法
+const (
+	MethodInit          = "Init"
+	MethodPostConstruct = "PostConstruct"
+	MethodInitMethod    = "init-method"
+	MethodPreDestroy    = "PreDestroy"
+	MethodDestroy       = "Destroy"
+	MethodDestroyMethod = "destroy-method"
+)
+
+// LifecycleError 生命周期回调失败错误
+type LifecycleError struct {
+	BeanName string
+	Phase    Phase
+	Method   string // 失败的回调方法
+	Cause    error
+}
+
+func (e *LifecycleError) Error() string {
+	var action string
+	switch e.Method {
+	case MethodInit:
+		action = "initialize bean"
+	case MethodPostConstruct:
+		action = "execute post construct for bean"
+	case MethodInitMethod:
+		action = "call init method for bean"
+	case MethodPreDestroy:
+		action = "execute pre destroy for bean"
+	case MethodDestroy:
+		action = "destroy bean"
+	case MethodDestroyMethod:
+		action = "call destroy method for bean"
+	default:
+		action = fmt.Sprintf("run %s callback for bean", e.Phase)
+	}
+	return fmt.Sprintf("failed to %s '%s': %v", action, e.BeanName, e.Cause)
+}
+
+func (e *LifecycleError) Is(target error) bool {
+	return target == ErrLifecycle
+}
+
+func (e *LifecycleError) Unwrap() error {
+	return e.Cause
+}
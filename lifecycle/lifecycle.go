@@ -1,13 +1,15 @@
 package lifecycle
 
 import (
-	"fmt"
+	"context"
 	"reflect"
 	"time"
 	"gospring/annotations"
 	"gospring/logging"
 )
 
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
 // LifecycleManager 生命周期管理器
 type LifecycleManager struct {
 	initOrder    []string
@@ -39,8 +41,20 @@ func (lm *LifecycleManager) GetLogger() logging.Logger {
 	return lm.logger
 }
 
-// ProcessInitialization 处理Bean初始化
+// ProcessInitialization 处理Bean初始化，并记录初始化顺序
 func (lm *LifecycleManager) ProcessInitialization(beanName string, instance interface{}) error {
+	if err := lm.InitializeInstance(beanName, instance); err != nil {
+		return err
+	}
+
+	// 记录初始化顺序
+	lm.initOrder = append(lm.initOrder, beanName)
+
+	return nil
+}
+
+// InitializeInstance 执行实例的初始化回调，不记录初始化顺序，适用于原型Bean的每个新实例
+func (lm *LifecycleManager) InitializeInstance(beanName string, instance interface{}) error {
 	start := time.Now()
 	componentType := reflect.TypeOf(instance).String()
 	
@@ -62,21 +76,21 @@ func (lm *LifecycleManager) ProcessInitialization(beanName string, instance inte
 	// 2. 检查是否实现了Initializer接口
 	if initializer, ok := instance.(annotations.Initializer); ok {
 		if err := initializer.Init(); err != nil {
-			initError = fmt.Errorf("failed to initialize bean '%s': %v", beanName, err)
+			initError = &LifecycleError{BeanName: beanName, Phase: PhaseInit, Method: MethodInit, Cause: err}
 		}
 	}
 
 	// 3. 检查是否实现了PostConstruct接口
 	if postConstruct, ok := instance.(annotations.PostConstruct); ok && initError == nil {
 		if err := postConstruct.PostConstruct(); err != nil {
-			initError = fmt.Errorf("failed to execute post construct for bean '%s': %v", beanName, err)
+			initError = &LifecycleError{BeanName: beanName, Phase: PhaseInit, Method: MethodPostConstruct, Cause: err}
 		}
 	}
 
 	// 4. 调用自定义初始化方法（通过反射）
 	if initError == nil {
 		if err := lm.callInitMethod(instance); err != nil {
-			initError = fmt.Errorf("failed to call init method for bean '%s': %v", beanName, err)
+			initError = &LifecycleError{BeanName: beanName, Phase: PhaseInit, Method: MethodInitMethod, Cause: err}
 		}
 	}
 
@@ -90,18 +104,52 @@ func (lm *LifecycleManager) ProcessInitialization(beanName string, instance inte
 		Error:         initError,
 	})
 
-	if initError != nil {
-		return initError
+	return initError
+}
+
+// ProcessDestruction 处理Bean销毁，并记录销毁顺序
+func (lm *LifecycleManager) ProcessDestruction(beanName string, instance interface{}) error {
+	return lm.ProcessDestructionContext(context.Background(), beanName, instance)
+}
+
+// ProcessDestructionContext 在 ctx 的时限内处理Bean销毁，并记录销毁顺序
+//
+// 销毁回调在 ctx 结束前没有返回时不再等待，返回原因为 ctx.Err() 的 *LifecycleError，
+// 回调继续在后台执行。接收 context.Context 的回调可以据此提前结束。
+func (lm *LifecycleManager) ProcessDestructionContext(ctx context.Context, beanName string, instance interface{}) error {
+	// 记录销毁顺序（逆序）
+	lm.destroyOrder = append([]string{beanName}, lm.destroyOrder...)
+
+	if ctx.Done() == nil {
+		return lm.DestroyInstanceContext(ctx, beanName, instance)
 	}
 
-	// 记录初始化顺序
-	lm.initOrder = append(lm.initOrder, beanName)
+	done := make(chan error, 1)
+	go func() {
+		done <- lm.DestroyInstanceContext(ctx, beanName, instance)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		// 回调与时限同时结束时以回调的结果为准
+		select {
+		case err := <-done:
+			return err
+		default:
+		}
+		return &LifecycleError{BeanName: beanName, Phase: PhaseDestroy, Method: MethodDestroy, Cause: ctx.Err()}
+	}
+}
 
-	return nil
+// DestroyInstance 执行实例的销毁回调，不记录销毁顺序，适用于原型Bean的实例
+func (lm *LifecycleManager) DestroyInstance(beanName string, instance interface{}) error {
+	return lm.DestroyInstanceContext(context.Background(), beanName, instance)
 }
 
-// ProcessDestruction 处理Bean销毁
-func (lm *LifecycleManager) ProcessDestruction(beanName string, instance interface{}) error {
+// DestroyInstanceContext 执行实例的销毁回调，接收 context.Context 的回调获得 ctx
+func (lm *LifecycleManager) DestroyInstanceContext(ctx context.Context, beanName string, instance interface{}) error {
 	start := time.Now()
 	componentType := reflect.TypeOf(instance).String()
 	
@@ -118,21 +166,29 @@ func (lm *LifecycleManager) ProcessDestruction(beanName string, instance interfa
 	// 1. 检查是否实现了PreDestroy接口
 	if preDestroy, ok := instance.(annotations.PreDestroy); ok {
 		if err := preDestroy.PreDestroy(); err != nil {
-			destroyError = fmt.Errorf("failed to execute pre destroy for bean '%s': %v", beanName, err)
+			destroyError = &LifecycleError{BeanName: beanName, Phase: PhaseDestroy, Method: MethodPreDestroy, Cause: err}
+		}
+	} else if preDestroy, ok := instance.(annotations.ContextPreDestroy); ok {
+		if err := preDestroy.PreDestroy(ctx); err != nil {
+			destroyError = &LifecycleError{BeanName: beanName, Phase: PhaseDestroy, Method: MethodPreDestroy, Cause: err}
 		}
 	}
 
 	// 2. 检查是否实现了Destroyer接口
 	if destroyer, ok := instance.(annotations.Destroyer); ok && destroyError == nil {
 		if err := destroyer.Destroy(); err != nil {
-			destroyError = fmt.Errorf("failed to destroy bean '%s': %v", beanName, err)
+			destroyError = &LifecycleError{BeanName: beanName, Phase: PhaseDestroy, Method: MethodDestroy, Cause: err}
+		}
+	} else if destroyer, ok := instance.(annotations.ContextDestroyer); ok && destroyError == nil {
+		if err := destroyer.Destroy(ctx); err != nil {
+			destroyError = &LifecycleError{BeanName: beanName, Phase: PhaseDestroy, Method: MethodDestroy, Cause: err}
 		}
 	}
 
 	// 3. 调用自定义销毁方法（通过反射）
 	if destroyError == nil {
-		if err := lm.callDestroyMethod(instance); err != nil {
-			destroyError = fmt.Errorf("failed to call destroy method for bean '%s': %v", beanName, err)
+		if err := lm.callDestroyMethod(ctx, instance); err != nil {
+			destroyError = &LifecycleError{BeanName: beanName, Phase: PhaseDestroy, Method: MethodDestroyMethod, Cause: err}
 		}
 	}
 
@@ -146,9 +202,6 @@ func (lm *LifecycleManager) ProcessDestruction(beanName string, instance interfa
 		Error:         destroyError,
 	})
 
-	// 记录销毁顺序（逆序）
-	lm.destroyOrder = append([]string{beanName}, lm.destroyOrder...)
-
 	return destroyError
 }
 
@@ -209,17 +262,38 @@ func (lm *LifecycleManager) callInitMethod(instance interface{}) error {
 	return nil
 }
 
-// callDestroyMethod 通过反射调用销毁方法
-func (lm *LifecycleManager) callDestroyMethod(instance interface{}) error {
+// calledByInterface 判断销毁方法是否已经通过 Destroyer、PreDestroy 等接口调用过
+func calledByInterface(instance interface{}, methodName string) bool {
+	switch methodName {
+	case "Destroy":
+		_, ok := instance.(annotations.Destroyer)
+		_, okCtx := instance.(annotations.ContextDestroyer)
+		return ok || okCtx
+	case "PreDestroy":
+		_, ok := instance.(annotations.PreDestroy)
+		_, okCtx := instance.(annotations.ContextPreDestroy)
+		return ok || okCtx
+	}
+	return false
+}
+
+// callDestroyMethod 通过反射调用销毁方法，方法可以没有参数或只接收一个 context.Context
+func (lm *LifecycleManager) callDestroyMethod(ctx context.Context, instance interface{}) error {
 	val := reflect.ValueOf(instance)
 	typ := reflect.TypeOf(instance)
 
-	// 查找destroy方法
+	// 查找destroy方法，只调用第一个匹配的方法；已经通过接口调用的 Destroy 和 PreDestroy 不再重复调用
 	destroyMethods := []string{"Destroy", "Close", "Cleanup", "PreDestroy"}
 	
 	for _, methodName := range destroyMethods {
 		method := val.MethodByName(methodName)
-		if method.IsValid() && method.Type().NumIn() == 0 {
+		if !method.IsValid() {
+			continue
+		}
+		if calledByInterface(instance, methodName) {
+			break
+		}
+		if method.Type().NumIn() == 0 {
 			// 调用无参数的销毁方法
 			results := method.Call(nil)
 			
@@ -231,6 +305,14 @@ func (lm *LifecycleManager) callDestroyMethod(instance interface{}) error {
 			}
 			break
 		}
+		if acceptsContext(method) {
+			if methodName != "Destroy" && methodName != "PreDestroy" {
+				if err := callWithContext(ctx, method); err != nil {
+					return err
+				}
+			}
+			break
+		}
 	}
 
 	// 检查结构体标签中的销毁方法
@@ -242,7 +324,11 @@ func (lm *LifecycleManager) callDestroyMethod(instance interface{}) error {
 		field := typ.Field(i)
 		if destroyMethod := field.Tag.Get("destroy-method"); destroyMethod != "" {
 			method := val.MethodByName(destroyMethod)
-			if method.IsValid() {
+			if method.IsValid() && acceptsContext(method) {
+				if err := callWithContext(ctx, method); err != nil {
+					return err
+				}
+			} else if method.IsValid() {
 				results := method.Call(nil)
 				if len(results) > 0 {
 					if err, ok := results[0].Interface().(error); ok && err != nil {
@@ -256,6 +342,22 @@ func (lm *LifecycleManager) callDestroyMethod(instance interface{}) error {
 	return nil
 }
 
+// acceptsContext 检查方法是否只接收一个 context.Context 参数
+func acceptsContext(method reflect.Value) bool {
+	return method.Type().NumIn() == 1 && method.Type().In(0) == contextType
+}
+
+// callWithContext 以 ctx 为参数调用方法，返回方法返回的错误
+func callWithContext(ctx context.Context, method reflect.Value) error {
+	results := method.Call([]reflect.Value{reflect.ValueOf(ctx)})
+	if len(results) > 0 {
+		if err, ok := results[0].Interface().(error); ok && err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetInitOrder 获取初始化顺序
 func (lm *LifecycleManager) GetInitOrder() []string {
 	return lm.initOrder
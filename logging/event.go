@@ -5,6 +5,7 @@ package logging
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -37,6 +38,35 @@ func (e *ComponentRegistered) String() string {
 		e.Timestamp.Format("15:04:05.000"), e.ComponentID, e.ComponentType, e.Scope)
 }
 
+// BeanDefinitionOverridden is emitted when a bean definition replaces an existing one with the same name.
+// Warning reports whether the override policy asks for overrides to be reported as warnings.
+type BeanDefinitionOverridden struct {
+	Timestamp     time.Time
+	ComponentID   string
+	PreviousType  string
+	ComponentType string
+	Warning       bool
+}
+
+func (e *BeanDefinitionOverridden) String() string {
+	return fmt.Sprintf("[%s] Bean definition overridden: %s (type: %s, previous type: %s)",
+		e.Timestamp.Format("15:04:05.000"), e.ComponentID, e.ComponentType, e.PreviousType)
+}
+
+// BeanSkipped is emitted when a conditional bean is removed because one of its conditions did not match.
+// Condition describes the condition that failed.
+type BeanSkipped struct {
+	Timestamp     time.Time
+	ComponentID   string
+	ComponentType string
+	Condition     string
+}
+
+func (e *BeanSkipped) String() string {
+	return fmt.Sprintf("[%s] Bean skipped: %s (type: %s, condition not matched: %s)",
+		e.Timestamp.Format("15:04:05.000"), e.ComponentID, e.ComponentType, e.Condition)
+}
+
 // ComponentScanned is emitted when a component is discovered during scanning.
 type ComponentScanned struct {
 	Timestamp    time.Time
@@ -83,6 +113,23 @@ func (e *DependencyInjectionFailed) String() string {
 		e.Timestamp.Format("15:04:05.000"), e.TargetType, e.FieldName, e.DependencyType, e.Error)
 }
 
+// CircularDependencyDetected is emitted when a dependency cycle is found while wiring.
+// Allowed reports whether the cycle was tolerated because circular references are enabled.
+type CircularDependencyDetected struct {
+	Timestamp time.Time
+	Chain     string
+	Allowed   bool
+}
+
+func (e *CircularDependencyDetected) String() string {
+	if e.Allowed {
+		return fmt.Sprintf("[%s] Circular dependency allowed: %s", 
+			e.Timestamp.Format("15:04:05.000"), e.Chain)
+	}
+	return fmt.Sprintf("[%s] Circular dependency rejected: %s", 
+		e.Timestamp.Format("15:04:05.000"), e.Chain)
+}
+
 // ComponentCreated is emitted when a component instance is created.
 type ComponentCreated struct {
 	Timestamp     time.Time
@@ -203,14 +250,42 @@ func (e *ContextStopping) String() string {
 }
 
 // ContextStopped is emitted when application context has stopped.
+// FailedBeans and TimedOutBeans list the beans whose destruction returned an error or did not finish in time.
 type ContextStopped struct {
-	Timestamp time.Time
-	Duration  time.Duration
+	Timestamp     time.Time
+	Duration      time.Duration
+	FailedBeans   []string
+	TimedOutBeans []string
 }
 
 func (e *ContextStopped) String() string {
-	return fmt.Sprintf("[%s] Application context stopped (duration: %v)", 
+	msg := fmt.Sprintf("[%s] Application context stopped (duration: %v", 
 		e.Timestamp.Format("15:04:05.000"), e.Duration)
+	if len(e.FailedBeans) > 0 {
+		msg += fmt.Sprintf(", failed: %s", strings.Join(e.FailedBeans, ", "))
+	}
+	if len(e.TimedOutBeans) > 0 {
+		msg += fmt.Sprintf(", timed out: %s", strings.Join(e.TimedOutBeans, ", "))
+	}
+	return msg + ")"
+}
+
+// ConfigChanged is emitted when reloaded property sources changed the configuration.
+// Error is set when the configuration properties could not be re-bound; the previous values are kept.
+type ConfigChanged struct {
+	Timestamp time.Time
+	Sources   []string
+	Keys      []string
+	Error     error
+}
+
+func (e *ConfigChanged) String() string {
+	if e.Error != nil {
+		return fmt.Sprintf("[%s] Configuration changed but could not be applied (keys: %s): %v",
+			e.Timestamp.Format("15:04:05.000"), strings.Join(e.Keys, ", "), e.Error)
+	}
+	return fmt.Sprintf("[%s] Configuration changed (sources: %s, keys: %s)",
+		e.Timestamp.Format("15:04:05.000"), strings.Join(e.Sources, ", "), strings.Join(e.Keys, ", "))
 }
 
 // ScanStarting is emitted when component scanning starts.
@@ -173,6 +173,16 @@ func (l *LeveledLogger) getEventLevel(event Event) LogLevel {
 	switch event.(type) {
 	case *DependencyInjectionFailed:
 		return LogLevelError
+	case *CircularDependencyDetected:
+		if e := event.(*CircularDependencyDetected); e.Allowed {
+			return LogLevelWarn
+		}
+		return LogLevelError
+	case *BeanDefinitionOverridden:
+		if e := event.(*BeanDefinitionOverridden); e.Warning {
+			return LogLevelWarn
+		}
+		return LogLevelInfo
 	case *LifecycleStarted:
 		if e := event.(*LifecycleStarted); e.Error != nil {
 			return LogLevelError
@@ -185,14 +195,24 @@ func (l *LeveledLogger) getEventLevel(event Event) LogLevel {
 		return LogLevelInfo
 	case *ComponentScanned, *DependencyInjected:
 		return LogLevelDebug
-	case *ComponentRegistered, *ComponentCreated, *ComponentDestroyed:
+	case *ComponentRegistered, *ComponentCreated, *ComponentDestroyed, *BeanSkipped:
 		return LogLevelInfo
 	case *LifecycleStarting, *LifecycleStopping:
 		return LogLevelDebug
-	case *ContextStarting, *ContextStarted, *ContextStopping, *ContextStopped:
+	case *ContextStopped:
+		if e := event.(*ContextStopped); len(e.FailedBeans) > 0 || len(e.TimedOutBeans) > 0 {
+			return LogLevelWarn
+		}
+		return LogLevelInfo
+	case *ContextStarting, *ContextStarted, *ContextStopping:
 		return LogLevelInfo
 	case *ContainerCreated:
 		return LogLevelInfo
+	case *ConfigChanged:
+		if e := event.(*ConfigChanged); e.Error != nil {
+			return LogLevelError
+		}
+		return LogLevelInfo
 	default:
 		return LogLevelInfo
 	}
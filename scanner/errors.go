@@ -0,0 +1,23 @@
+package scanner
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrNotAComponent 用于 errors.Is 判断类型未标记为组件
+var ErrNotAComponent = errors.New("not a component")
+
+// NotAComponentError 类型未标记为组件错误
+type NotAComponentError struct {
+	Type reflect.Type
+}
+
+func (e *NotAComponentError) Error() string {
+	return fmt.Sprintf("type %v is not a component", e.Type)
+}
+
+func (e *NotAComponentError) Is(target error) bool {
+	return target == ErrNotAComponent
+}
@@ -5,10 +5,13 @@ import (
 	"reflect"
 	"strings"
 	"time"
+	"gospring/annotations"
 	"gospring/container"
 	"gospring/logging"
 )
 
+var annotationUtils = annotations.NewAnnotationUtils()
+
 // ComponentScanner 组件扫描器
 type ComponentScanner struct {
 	container *container.Container
@@ -77,25 +80,14 @@ func (s *ComponentScanner) ScanComponent(instance interface{}) error {
 			ComponentName: "",
 			Duration:      time.Since(start),
 			Success:       false,
-			Error:         fmt.Errorf("type %v is not a component", typ),
+			Error:         &NotAComponentError{Type: typ},
 		})
-		return fmt.Errorf("type %v is not a component", typ)
+		return &NotAComponentError{Type: typ}
 	}
 
-	// 检查是否为单例
-	singleton := s.isSingleton(typ)
-	scope := "prototype"
-	if singleton {
-		scope = "singleton"
-	}
-
-	var regError error
-	// 注册到容器
-	if singleton {
-		regError = s.container.RegisterSingleton(componentName, instance)
-	} else {
-		regError = s.container.RegisterPrototype(componentName, instance)
-	}
+	// 按作用域注册到容器，profile 标签不成立时容器跳过注册
+	scope := s.getScope(typ)
+	regError := s.container.RegisterScoped(componentName, instance, scope)
 
 	// 记录扫描完成事件
 	s.logger.LogEvent(&logging.ScanCompleted{
@@ -126,6 +118,11 @@ func (s *ComponentScanner) getComponentName(typ reflect.Type) string {
 		}
 	}
 
+	// 配置属性对象以类型名称作为组件名
+	if annotationUtils.GetConfigPrefix(typ) != "" {
+		return strings.ToLower(typ.Name())
+	}
+
 	// 检查类型是否有特定的命名约定
 	typeName := typ.Name()
 	if strings.HasSuffix(typeName, "Service") || 
@@ -138,24 +135,16 @@ func (s *ComponentScanner) getComponentName(typ reflect.Type) string {
 	return ""
 }
 
-// isSingleton 检查是否为单例
-func (s *ComponentScanner) isSingleton(typ reflect.Type) bool {
-	for i := 0; i < typ.NumField(); i++ {
-		field := typ.Field(i)
-		if singletonTag := field.Tag.Get("singleton"); singletonTag != "" {
-			return singletonTag == "true"
-		}
-	}
-	
-	// 默认为单例
-	return true
+// getScope 获取组件的作用域，支持 singleton、scope 标签和自定义作用域
+func (s *ComponentScanner) getScope(typ reflect.Type) string {
+	return annotationUtils.GetScope(typ)
 }
 
 // ScanAndRegister 扫描多个组件并注册
 func (s *ComponentScanner) ScanAndRegister(components ...interface{}) error {
 	for _, component := range components {
 		if err := s.ScanComponent(component); err != nil {
-			return fmt.Errorf("failed to scan component %T: %v", component, err)
+			return fmt.Errorf("failed to scan component %T: %w", component, err)
 		}
 	}
 	return nil
@@ -199,8 +188,8 @@ func (s *ComponentScanner) AutoScan(instance interface{}) error {
 }
 
 // RegisterWithInterface 注册实现了特定接口的组件
-func (s *ComponentScanner) RegisterWithInterface(interfaceType reflect.Type, implementation interface{}, name string) error {
-	return s.container.RegisterByInterface(interfaceType, implementation, name)
+func (s *ComponentScanner) RegisterWithInterface(interfaceType reflect.Type, implementation interface{}, name string, opts ...container.BeanOption) error {
+	return s.container.RegisterByInterface(interfaceType, implementation, name, opts...)
 }
 
 // ScanPackageComponents 扫描包中的组件（模拟实现）
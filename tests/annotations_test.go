@@ -138,6 +138,13 @@ func TestAnnotationUtils_GetScope(t *testing.T) {
 	normalType := reflect.TypeOf(&TestAnnotationService{})
 	scope = utils.GetScope(normalType)
 	assert.Equal(t, "singleton", scope)
+
+	// singleton="false" 等价于原型
+	assert.Equal(t, "prototype", utils.GetScope(reflect.TypeOf(&TestPrototypeService{})))
+
+	// 自定义作用域
+	assert.Equal(t, "tenant", utils.GetScope(reflect.TypeOf(&TenantSettings{})))
+	assert.False(t, utils.IsSingleton(reflect.TypeOf(&TenantSettings{})))
 }
 
 func TestAnnotationUtils_HasTag(t *testing.T) {
@@ -0,0 +1,177 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"gospring"
+	"gospring/container"
+	"gospring/context"
+	"gospring/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+type RunLog struct {
+	_     string `component:"runLog"`
+	mutex sync.Mutex
+	calls []string
+}
+
+func (l *RunLog) add(call string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.calls = append(l.calls, call)
+}
+
+type ImportRunner struct {
+	_   string  `component:"importRunner" order:"2"`
+	Log *RunLog `inject:""`
+}
+
+func (r *ImportRunner) Run(args []string) error {
+	r.Log.add("import:" + strings.Join(args, " "))
+	return nil
+}
+
+type ReportRunner struct {
+	_    string                         `component:"reportRunner" order:"1"`
+	Log  *RunLog                        `inject:""`
+	Args *gospring.ApplicationArguments `inject:""`
+}
+
+func (r *ReportRunner) Run(args *gospring.ApplicationArguments) error {
+	format, _ := args.OptionValue("report.format")
+	r.Log.add("report:" + format + ":" + strings.Join(r.Args.NonOptionArgs(), ","))
+	return nil
+}
+
+type FailingRunner struct {
+	Err error
+}
+
+func (r *FailingRunner) Run(args []string) error {
+	return r.Err
+}
+
+type FailingCloser struct{}
+
+func (c *FailingCloser) Close() error {
+	return errors.New("connection reset")
+}
+
+type ReleasingPool struct {
+	released bool
+}
+
+func (p *ReleasingPool) Destroy() error {
+	p.released = true
+	return nil
+}
+
+type WarmupCache struct{}
+
+func (c *WarmupCache) Init() error {
+	return errors.New("warmup failed")
+}
+
+type AppPort struct {
+	_    string `component:"appPort"`
+	Port int    `value:"${server.port}"`
+}
+
+type quotaExceeded struct{}
+
+func (quotaExceeded) Error() string { return "quota exceeded" }
+func (quotaExceeded) ExitCode() int { return 42 }
+
+func newTestApplication(components ...interface{}) *gospring.Application {
+	app := gospring.NewApplication(components...)
+	app.Context().SetLogger(logging.NopLogger)
+	return app
+}
+
+func TestApplication_Runners(t *testing.T) {
+	log := &RunLog{}
+	app := newTestApplication(log, &ImportRunner{}, &ReportRunner{})
+
+	args := []string{"--report.format=csv", "--gospring.main.keep-alive=false", "input.csv"}
+	err := app.Run(args)
+	assert.NoError(t, err)
+	assert.Equal(t, gospring.ExitOK, gospring.ExitCode(err))
+
+	// 运行器按排序值调用，命令行参数同时作为属性源
+	assert.Equal(t, []string{
+		"report:csv:input.csv",
+		"import:--report.format=csv --gospring.main.keep-alive=false input.csv",
+	}, log.calls)
+	assert.False(t, app.Context().IsStarted())
+}
+
+func TestApplication_ExitCodes(t *testing.T) {
+	// 启动失败
+	err := newTestApplication(&AppPort{}).Run([]string{"--gospring.main.keep-alive=false", "--server.port=abc"})
+	assert.ErrorIs(t, err, container.ErrValueInjection)
+	assert.Equal(t, gospring.ExitStartupFailure, gospring.ExitCode(err))
+
+	// 启动中途失败时销毁已经初始化的Bean，销毁的错误与启动错误一起返回
+	pool := &ReleasingPool{}
+	app := newTestApplication()
+	app.Context().RegisterSingleton("releasingPool", pool)
+	app.Context().RegisterSingleton("failingCloser", &FailingCloser{})
+	app.Context().RegisterSingleton("warmupCache", &WarmupCache{})
+	err = app.Run([]string{"--gospring.main.keep-alive=false"})
+	assert.Equal(t, gospring.ExitStartupFailure, gospring.ExitCode(err))
+	assert.True(t, pool.released)
+	assert.ErrorIs(t, err, context.ErrShutdown)
+	assert.Contains(t, err.Error(), "warmup failed")
+	assert.Contains(t, err.Error(), "connection reset")
+
+	// 运行器失败时停止上下文
+	app = newTestApplication()
+	app.Context().RegisterSingleton("failingRunner", &FailingRunner{Err: errors.New("import failed")})
+	err = app.Run(nil)
+	assert.Equal(t, gospring.ExitRunnerFailure, gospring.ExitCode(err))
+	assert.Contains(t, err.Error(), "failingRunner")
+	assert.False(t, app.Context().IsStarted())
+
+	// 停止上下文的错误与运行器的错误一起返回，退出码仍为运行器失败
+	app = newTestApplication()
+	app.Context().RegisterSingleton("failingRunner", &FailingRunner{Err: errors.New("import failed")})
+	app.Context().RegisterSingleton("failingCloser", &FailingCloser{})
+	err = app.Run(nil)
+	assert.Equal(t, gospring.ExitRunnerFailure, gospring.ExitCode(err))
+	assert.ErrorIs(t, err, context.ErrShutdown)
+	assert.Contains(t, err.Error(), "import failed")
+	assert.Contains(t, err.Error(), "connection reset")
+
+	// 错误可以指定退出码
+	app = newTestApplication()
+	app.Context().RegisterSingleton("failingRunner", &FailingRunner{Err: quotaExceeded{}})
+	assert.Equal(t, 42, gospring.ExitCode(app.Run(nil)))
+}
+
+func TestApplication_Shutdown(t *testing.T) {
+	app := newTestApplication()
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Run(nil)
+	}()
+
+	// 运行器执行完后等待关闭
+	select {
+	case err := <-done:
+		t.Fatalf("Run 应该等待关闭, 得到 %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	app.Shutdown()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Shutdown 之后 Run 应该返回")
+	}
+	assert.False(t, app.Context().IsStarted())
+}
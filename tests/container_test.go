@@ -1,9 +1,18 @@
 package tests
 
 import (
+	stdcontext "context"
+	"errors"
+	"net"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 	"gospring/container"
+	"gospring/context"
+	"gospring/environment"
+	"gospring/logging"
+	"gospring/scanner"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -220,4 +229,1004 @@ func TestContainer_Destroy(t *testing.T) {
 	assert.False(t, c.HasBean("testService"))
 	beans := c.ListBeans()
 	assert.Len(t, beans, 0)
-}
\ No newline at end of file
+}
+// 通过构造函数创建的服务，字段保持不导出
+type ProviderUserService struct {
+	repo TestRepository
+	name string
+}
+
+func NewProviderUserService(repo TestRepository) (*ProviderUserService, error) {
+	return &ProviderUserService{repo: repo, name: "provided"}, nil
+}
+
+type ProviderConsumer struct {
+	UserService *ProviderUserService `inject:""`
+}
+
+func TestContainer_RegisterProvider(t *testing.T) {
+	c := container.NewContainer()
+
+	repository := &TestRepositoryImpl{}
+	repoInterface := reflect.TypeOf((*TestRepository)(nil)).Elem()
+	assert.NoError(t, c.RegisterByInterface(repoInterface, repository, "testRepository"))
+
+	err := c.RegisterProvider("userService", NewProviderUserService)
+	assert.NoError(t, err)
+
+	consumer := &ProviderConsumer{}
+	assert.NoError(t, c.RegisterSingleton("consumer", consumer))
+	assert.NoError(t, c.WireAll())
+
+	bean := c.GetBean("userService")
+	service, ok := bean.(*ProviderUserService)
+	assert.True(t, ok)
+	assert.Equal(t, "provided", service.name)
+	assert.Same(t, repository, service.repo)
+	assert.Same(t, service, c.GetBean("userService"))
+	assert.Same(t, service, consumer.UserService)
+
+	// 也可以按返回值类型获取
+	assert.Same(t, service, c.GetBeanByType(reflect.TypeOf(service)))
+
+	beanDef := c.GetBeanDefinition("userService")
+	assert.NotNil(t, beanDef.Factory)
+	assert.Equal(t, reflect.TypeOf(ProviderUserService{}), beanDef.Type)
+}
+
+func TestContainer_RegisterProvider_Error(t *testing.T) {
+	c := container.NewContainer()
+
+	c.RegisterProvider("failing", func() (*TestServiceImpl, error) {
+		return nil, assert.AnError
+	})
+	err := c.WireAll()
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Nil(t, c.GetBean("failing"))
+
+	// 缺少依赖
+	c.RegisterProvider("missingDependency", NewProviderUserService)
+	assert.Nil(t, c.GetBean("missingDependency"))
+}
+
+func TestContainer_RegisterProvider_InvalidSignature(t *testing.T) {
+	c := container.NewContainer()
+
+	assert.Error(t, c.RegisterProvider("notFunc", &TestServiceImpl{}))
+	assert.Error(t, c.RegisterProvider("noResult", func() {}))
+	assert.Error(t, c.RegisterProvider("badError", func() (*TestServiceImpl, string) { return nil, "" }))
+	assert.False(t, c.HasBean("notFunc"))
+}
+
+func TestContainer_InitializationOrder(t *testing.T) {
+	c := container.NewContainer()
+
+	c.RegisterSingleton("testController", &TestController{})
+	c.RegisterSingleton("testRepository", &TestRepositoryImpl{})
+	c.RegisterSingleton("testService", &TestServiceImpl{name: "ordered"})
+
+	order, err := c.InitializationOrder()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"testService", "testRepository", "testController"}, order)
+	assert.Equal(t, []string{"testService", "testRepository"}, c.GetDependencies("testController"))
+
+	// ListBeans 按注册顺序返回
+	assert.Equal(t, []string{"testController", "testRepository", "testService"}, c.ListBeans())
+}
+
+// 用于循环依赖测试的组件
+type CycleOrderService struct {
+	ProductService *CycleProductService `inject:"productService"`
+}
+
+type CycleProductService struct {
+	StockService *CycleStockService `inject:"stockService"`
+}
+
+type CycleStockService struct {
+	OrderService *CycleOrderService `inject:"orderService"`
+}
+
+type CyclePrototype struct {
+	Self *CyclePrototype `inject:"cyclePrototype"`
+}
+
+func TestContainer_CircularDependency(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+
+	c.RegisterSingleton("orderService", &CycleOrderService{})
+	c.RegisterSingleton("productService", &CycleProductService{})
+	c.RegisterSingleton("stockService", &CycleStockService{})
+
+	err := c.WireAll()
+	assert.Error(t, err)
+
+	var cycleErr *container.CircularDependencyError
+	assert.True(t, errors.As(err, &cycleErr))
+	assert.Equal(t, []string{"orderService", "productService", "stockService", "orderService"}, cycleErr.BeanNames())
+	assert.Equal(t, "circular dependency detected: orderService (field ProductService) -> "+
+		"productService (field StockService) -> stockService (field OrderService) -> orderService", err.Error())
+}
+
+func TestContainer_AllowCircularReferences(t *testing.T) {
+	logger := &TestLogger{}
+	c := container.NewContainerWithLogger(logger)
+	c.SetAllowCircularReferences(true)
+
+	order := &CycleOrderService{}
+	c.RegisterSingleton("orderService", order)
+	c.RegisterSingleton("productService", &CycleProductService{})
+	c.RegisterSingleton("stockService", &CycleStockService{})
+
+	assert.NoError(t, c.WireAll())
+	assert.Same(t, order, order.ProductService.StockService.OrderService)
+
+	var warnings int
+	for _, event := range logger.GetEvents() {
+		if e, ok := event.(*logging.CircularDependencyDetected); ok {
+			assert.True(t, e.Allowed)
+			assert.Contains(t, e.Chain, "orderService (field ProductService)")
+			warnings++
+		}
+	}
+	assert.Equal(t, 1, warnings)
+
+	// 原型之间的循环即使开启也不允许
+	c.RegisterPrototype("cyclePrototype", &CyclePrototype{})
+	err := c.WireAll()
+	var cycleErr *container.CircularDependencyError
+	assert.True(t, errors.As(err, &cycleErr))
+	assert.Equal(t, []string{"cyclePrototype", "cyclePrototype"}, cycleErr.BeanNames())
+}
+
+func TestContainer_PrototypeCycleDoesNotRecurse(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+	c.RegisterPrototype("cyclePrototype", &CyclePrototype{})
+
+	// 创建时检测到循环，不会无限递归，严格模式下创建失败
+	assert.Nil(t, c.GetBean("cyclePrototype"))
+
+	// 非严格模式下循环字段保持为nil
+	c.SetStrictWiring(false)
+	bean := c.GetBean("cyclePrototype")
+	assert.NotNil(t, bean)
+	assert.Nil(t, bean.(*CyclePrototype).Self)
+}
+
+type ConcurrentCycleA struct {
+	B *ConcurrentCycleB `inject:"concurrentB"`
+}
+
+type ConcurrentCycleB struct {
+	A *ConcurrentCycleA `inject:"concurrentA"`
+}
+
+func TestContainer_ConcurrentSingletonCreation(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+
+	// 两个构造函数都开始执行后才注入字段，使两个协程各自持有一个单例的创建
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+	var onceA, onceB sync.Once
+	c.RegisterProvider("concurrentA", func() *ConcurrentCycleA {
+		onceA.Do(arrived.Done)
+		arrived.Wait()
+		return &ConcurrentCycleA{}
+	})
+	c.RegisterProvider("concurrentB", func() *ConcurrentCycleB {
+		onceB.Do(arrived.Done)
+		arrived.Wait()
+		return &ConcurrentCycleB{}
+	})
+
+	errs := make(chan error, 2)
+	for _, name := range []string{"concurrentA", "concurrentB"} {
+		go func(name string) {
+			_, err := c.LookupBean(name)
+			errs <- err
+		}(name)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errs:
+			assert.True(t, errors.Is(err, container.ErrCircularDependency), "unexpected error: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("concurrent creation of mutually dependent singletons deadlocked")
+		}
+	}
+
+	// 没有循环时并发获取只创建一次
+	created := 0
+	c.RegisterProvider("concurrentService", func() *TestServiceImpl {
+		created++
+		time.Sleep(10 * time.Millisecond)
+		return &TestServiceImpl{name: "concurrent"}
+	})
+
+	var wg sync.WaitGroup
+	beans := make([]interface{}, 8)
+	for i := range beans {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			beans[i] = c.GetBean("concurrentService")
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, created)
+	for _, bean := range beans {
+		assert.Same(t, beans[0], bean)
+	}
+}
+
+func TestContainer_TypedErrors(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+
+	// 找不到Bean
+	bean, err := c.LookupBean("missing")
+	assert.Nil(t, bean)
+	var notFound *container.BeanNotFoundError
+	assert.True(t, errors.As(err, &notFound))
+	assert.Equal(t, "missing", notFound.Name)
+	assert.ErrorIs(t, err, container.ErrBeanNotFound)
+
+	_, err = c.LookupBeanByType(reflect.TypeOf(&TestServiceImpl{}))
+	assert.True(t, errors.As(err, &notFound))
+	assert.Equal(t, reflect.TypeOf(&TestServiceImpl{}), notFound.Type)
+
+	// 重复注册
+	c.RegisterSingleton("testService", &TestServiceImpl{})
+	err = c.RegisterSingleton("testService", &TestServiceImpl{})
+	var duplicate *container.DuplicateBeanError
+	assert.True(t, errors.As(err, &duplicate))
+	assert.Equal(t, "testService", duplicate.Name)
+	assert.ErrorIs(t, err, container.ErrDuplicateBean)
+
+	// 创建失败，包装构造函数返回的错误
+	c.RegisterProvider("failing", func() (*TestController, error) {
+		return nil, assert.AnError
+	})
+	_, err = c.LookupBean("failing")
+	var creation *container.BeanCreationError
+	assert.True(t, errors.As(err, &creation))
+	assert.Equal(t, "failing", creation.Name)
+	assert.ErrorIs(t, err, container.ErrBeanCreation)
+	assert.ErrorIs(t, err, assert.AnError)
+
+	// 构造函数参数无法满足
+	c.RegisterProvider("userService", NewProviderUserService)
+	_, err = c.LookupBean("userService")
+	var unsatisfied *container.UnsatisfiedDependencyError
+	assert.True(t, errors.As(err, &unsatisfied))
+	assert.Equal(t, "arg0", unsatisfied.FieldName)
+	assert.ErrorIs(t, err, container.ErrUnsatisfiedDependency)
+	assert.ErrorIs(t, err, container.ErrBeanNotFound)
+}
+
+func TestGeneric_RegisterAndResolve(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+
+	service := &TestServiceImpl{name: "generic"}
+	assert.NoError(t, container.Bind[TestService](c, "testService", service))
+	assert.NoError(t, container.Register(c, "testRepository", &TestRepositoryImpl{}))
+
+	resolved, err := container.Resolve[TestService](c)
+	assert.NoError(t, err)
+	assert.Same(t, service, resolved)
+
+	impl, err := container.Resolve[*TestServiceImpl](c)
+	assert.NoError(t, err)
+	assert.Same(t, service, impl)
+
+	named, err := container.ResolveNamed[TestService](c, "testService")
+	assert.NoError(t, err)
+	assert.Equal(t, "generic", named.GetName())
+
+	assert.Same(t, service, container.MustResolve[TestService](c))
+
+	// 类型不匹配时返回错误而不是零值
+	_, err = container.ResolveNamed[TestService](c, "testRepository")
+	var typeErr *container.BeanNotOfRequiredTypeError
+	assert.True(t, errors.As(err, &typeErr))
+	assert.ErrorIs(t, err, container.ErrBeanNotOfRequiredType)
+
+	_, err = container.Resolve[*TestController](c)
+	assert.ErrorIs(t, err, container.ErrBeanNotFound)
+	assert.Panics(t, func() { container.MustResolve[*TestController](c) })
+
+	// Bind 要求接口类型
+	assert.Error(t, container.Bind[*TestServiceImpl](c, "notInterface", service))
+}
+
+func TestGeneric_ResolveAll(t *testing.T) {
+	ctx := context.NewApplicationContextWithLogger(logging.NopLogger)
+
+	first := &TestServiceImpl{name: "first"}
+	second := &TestServiceImpl{name: "second"}
+	assert.NoError(t, container.Register(ctx, "first", first))
+	assert.NoError(t, container.Register(ctx, "second", second))
+	assert.NoError(t, container.Register(ctx, "repository", &TestRepositoryImpl{}))
+
+	services, err := container.ResolveAll[TestService](ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []TestService{first, second}, services)
+
+	named, err := container.ResolveNamed[*TestServiceImpl](ctx, "second")
+	assert.NoError(t, err)
+	assert.Same(t, second, named)
+}
+
+// 按接口注入的组件
+type AutoBindConsumer struct {
+	Service    TestService    `inject:""`
+	Repository TestRepository `inject:""`
+}
+
+func TestContainer_AutomaticInterfaceBinding(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+
+	service := &TestServiceImpl{name: "auto"}
+	repository := &TestRepositoryImpl{}
+	consumer := &AutoBindConsumer{}
+	c.RegisterSingleton("consumer", consumer)
+	c.RegisterSingleton("testService", service)
+	c.RegisterSingleton("testRepository", repository)
+
+	// 无需 RegisterByInterface，接口字段按唯一实现注入
+	assert.NoError(t, c.WireAll())
+	assert.Same(t, service, consumer.Service)
+	assert.Same(t, repository, consumer.Repository)
+
+	// 依赖图同样能识别接口依赖
+	assert.Equal(t, []string{"testService", "testRepository"}, c.GetDependencies("consumer"))
+
+	serviceType := reflect.TypeOf((*TestService)(nil)).Elem()
+	assert.Same(t, service, c.GetBeanByType(serviceType))
+}
+
+func TestContainer_AmbiguousInterfaceBinding(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+	serviceType := reflect.TypeOf((*TestService)(nil)).Elem()
+
+	c.RegisterSingleton("first", &TestServiceImpl{name: "first"})
+	first, err := c.LookupBeanByType(serviceType)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", first.(TestService).GetName())
+
+	// 注册第二个实现后缓存失效，再次查找时报告歧义
+	c.RegisterSingleton("second", &TestServiceImpl{name: "second"})
+	_, err = c.LookupBeanByType(serviceType)
+
+	var ambiguous *container.NoUniqueBeanError
+	assert.True(t, errors.As(err, &ambiguous))
+	assert.Equal(t, []string{"first", "second"}, ambiguous.Candidates)
+	assert.ErrorIs(t, err, container.ErrNoUniqueBean)
+	assert.Contains(t, err.Error(), "first, second")
+
+	// 注入时同样失败
+	err = c.InjectDependencies(&AutoBindConsumer{})
+	assert.ErrorIs(t, err, container.ErrNoUniqueBean)
+}
+
+type DataSource interface {
+	URL() string
+}
+
+type MySQLDataSource struct {
+	_ string `primary:"true" qualifier:"mysql"`
+}
+
+func (d *MySQLDataSource) URL() string { return "mysql://" }
+
+type PostgresDataSource struct{}
+
+func (d *PostgresDataSource) URL() string { return "postgres://" }
+
+type QualifierConsumer struct {
+	Default  DataSource `inject:""`
+	MySQL    DataSource `inject:"" qualifier:"mysql"`
+	Postgres DataSource `inject:"" qualifier:"postgres"`
+}
+
+func TestContainer_PrimaryAndQualifier(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+
+	mysql := &MySQLDataSource{}
+	postgres := &PostgresDataSource{}
+	consumer := &QualifierConsumer{}
+	c.RegisterSingleton("consumer", consumer)
+	c.RegisterSingleton("mysqlDataSource", mysql)
+	c.RegisterSingleton("postgresDataSource", postgres, container.Qualifier("postgres"))
+
+	assert.NoError(t, c.WireAll())
+	assert.Same(t, mysql, consumer.Default)
+	assert.Same(t, mysql, consumer.MySQL)
+	assert.Same(t, postgres, consumer.Postgres)
+	assert.Equal(t, []string{"mysqlDataSource", "mysqlDataSource", "postgresDataSource"}, c.GetDependencies("consumer"))
+
+	// 后注册的Bean不会覆盖按类型查找的结果
+	dataSourceType := reflect.TypeOf((*DataSource)(nil)).Elem()
+	assert.Same(t, mysql, c.GetBeanByType(dataSourceType))
+
+	// Bean名称同样可以作为限定符
+	byName, err := container.ResolveQualified[DataSource](c, "postgresDataSource")
+	assert.NoError(t, err)
+	assert.Same(t, postgres, byName)
+
+	_, err = c.LookupQualifiedBean(dataSourceType, "oracle")
+	assert.ErrorIs(t, err, container.ErrBeanNotFound)
+	assert.Contains(t, err.Error(), "qualifier 'oracle'")
+}
+
+func TestContainer_PrimaryOption(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+	serviceType := reflect.TypeOf((*TestService)(nil)).Elem()
+
+	c.RegisterSingleton("first", &TestServiceImpl{name: "first"})
+	c.RegisterSingleton("second", &TestServiceImpl{name: "second"}, container.Primary())
+
+	service, err := c.LookupBeanByType(serviceType)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", service.(TestService).GetName())
+	assert.Equal(t, "second", c.GetBeanByType(reflect.TypeOf(&TestServiceImpl{})).(TestService).GetName())
+
+	// 多个首选Bean时仍然报告歧义
+	c.RegisterSingleton("third", &TestServiceImpl{name: "third"}, container.Primary())
+	_, err = c.LookupBeanByType(serviceType)
+	assert.ErrorIs(t, err, container.ErrNoUniqueBean)
+}
+
+func TestContainer_BindSameInterfaceTwice(t *testing.T) {
+	// 先注册的首选Bean不会被后绑定的实现覆盖
+	c := container.NewContainerWithLogger(logging.NopLogger)
+	assert.NoError(t, container.Bind[TestService](c, "first", &TestServiceImpl{name: "first"}, container.Primary()))
+	assert.NoError(t, container.Bind[TestService](c, "second", &TestServiceImpl{name: "second"}))
+
+	service, err := container.Resolve[TestService](c)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", service.GetName())
+
+	// 两个都不是首选Bean时报告歧义，而不是返回后绑定的实现
+	c = container.NewContainerWithLogger(logging.NopLogger)
+	assert.NoError(t, container.Bind[TestService](c, "first", &TestServiceImpl{name: "first"}))
+	assert.NoError(t, container.Bind[TestService](c, "second", &TestServiceImpl{name: "second"}))
+
+	_, err = container.Resolve[TestService](c)
+	assert.ErrorIs(t, err, container.ErrNoUniqueBean)
+}
+
+type Validator interface {
+	Validate(value string) string
+}
+
+type LengthValidator struct {
+	_ string `order:"20"`
+}
+
+func (v *LengthValidator) Validate(value string) string { return "length" }
+
+type FormatValidator struct{}
+
+func (v *FormatValidator) Validate(value string) string { return "format" }
+
+type NotEmptyValidator struct{}
+
+func (v *NotEmptyValidator) Validate(value string) string { return "notEmpty" }
+func (v *NotEmptyValidator) Order() int                   { return 10 }
+
+// ValidatorChain 自身也是 Validator，不会被注入到自己的切片中
+type ValidatorChain struct {
+	Validators []Validator          `inject:""`
+	ByName     map[string]Validator `inject:""`
+}
+
+func (v *ValidatorChain) Validate(value string) string { return "chain" }
+
+type OptionalCollectionConsumer struct {
+	Handlers []TestRepository `inject:",optional"`
+}
+
+func TestContainer_CollectionInjection(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+
+	chain := &ValidatorChain{}
+	c.RegisterSingleton("chain", chain)
+	c.RegisterSingleton("format", &FormatValidator{})
+	c.RegisterSingleton("length", &LengthValidator{})
+	c.RegisterSingleton("notEmpty", &NotEmptyValidator{})
+	c.RegisterSingleton("first", &FormatValidator{}, container.Order(1))
+
+	assert.NoError(t, c.WireAll())
+
+	// 按排序值排列，未声明排序的Bean排在最后并保持注册顺序
+	var names []string
+	for _, v := range chain.Validators {
+		names = append(names, v.Validate(""))
+	}
+	assert.Equal(t, []string{"format", "notEmpty", "length", "format"}, names)
+	assert.Len(t, chain.ByName, 4)
+	assert.Contains(t, chain.ByName, "notEmpty")
+	assert.NotContains(t, chain.ByName, "chain")
+
+	// 集合中的Bean都在声明集合的Bean之前初始化
+	order, err := c.InitializationOrder()
+	assert.NoError(t, err)
+	assert.Equal(t, "chain", order[len(order)-1])
+}
+
+func TestContainer_EmptyCollectionInjection(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+
+	err := c.InjectDependencies(&ValidatorChain{})
+	assert.ErrorIs(t, err, container.ErrBeanNotFound)
+
+	consumer := &OptionalCollectionConsumer{}
+	assert.NoError(t, c.InjectDependencies(consumer))
+	assert.Nil(t, consumer.Handlers)
+}
+
+type ProviderJob struct {
+	ID int
+}
+
+type HeavyService struct{}
+
+type DeferredConsumer struct {
+	Jobs  container.Provider[*ProviderJob] `inject:""`
+	Heavy container.Lazy[*HeavyService]    `inject:"heavyService"`
+}
+
+func TestContainer_ProviderAndLazyInjection(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+
+	created := 0
+	consumer := &DeferredConsumer{}
+	c.RegisterSingleton("consumer", consumer)
+	c.RegisterPrototype("job", &ProviderJob{})
+	c.RegisterProvider("heavyService", func() *HeavyService {
+		created++
+		return &HeavyService{}
+	}, container.LazyInit())
+
+	assert.NoError(t, c.WireAll())
+	assert.Equal(t, 0, created)
+	assert.Empty(t, c.GetDependencies("consumer"))
+
+	// Provider 每次调用都解析，原型Bean每次返回新实例
+	first, second := consumer.Jobs.Get(), consumer.Jobs.Get()
+	assert.NotNil(t, first)
+	assert.NotSame(t, first, second)
+
+	// Lazy 首次调用时创建并缓存
+	heavy := consumer.Heavy.Get()
+	assert.NotNil(t, heavy)
+	assert.Same(t, heavy, consumer.Heavy.Get())
+	assert.Equal(t, 1, created)
+}
+
+func TestContainer_DeferredInjectionErrors(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+
+	// 严格模式下，目标无法解析的 Provider 同样导致装配失败
+	err := c.InjectDependencies(&DeferredConsumer{})
+	assert.ErrorIs(t, err, container.ErrBeanNotFound)
+
+	// 未绑定到容器的 Provider 返回错误
+	var unbound container.Provider[*ProviderJob]
+	_, err = unbound.Lookup()
+	assert.Error(t, err)
+	assert.Nil(t, unbound.Get())
+}
+
+type PrototypeTemplate struct {
+	Name       string
+	Tags       []string
+	Limits     map[string]int
+	Options    *PrototypeOptions
+	Shared     *PrototypeShared
+	Repository TestRepository `inject:""`
+	retries    int
+}
+
+type PrototypeOptions struct {
+	Verbose bool
+}
+
+type PrototypeShared struct {
+	mutex sync.Mutex
+}
+
+type ClonedPrototype struct {
+	Tags []string
+}
+
+func (p *ClonedPrototype) Clone() *ClonedPrototype {
+	return &ClonedPrototype{Tags: append([]string(nil), p.Tags...)}
+}
+
+func TestContainer_PrototypeCopiesTemplate(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+
+	repository := &TestRepositoryImpl{}
+	shared := &PrototypeShared{}
+	c.RegisterSingleton("testRepository", repository)
+	c.RegisterPrototype("template", &PrototypeTemplate{
+		Name:    "report",
+		Tags:    []string{"a"},
+		Limits:  map[string]int{"rows": 10},
+		Options: &PrototypeOptions{Verbose: true},
+		Shared:  shared,
+		retries: 3,
+	})
+
+	first := c.GetBean("template").(*PrototypeTemplate)
+	second := c.GetBean("template").(*PrototypeTemplate)
+
+	// 保留注册时配置的字段，并完成依赖注入
+	assert.NotSame(t, first, second)
+	assert.Equal(t, "report", first.Name)
+	assert.Equal(t, 3, first.retries)
+	assert.True(t, first.Options.Verbose)
+	assert.Same(t, repository, first.Repository)
+
+	// 指针、切片和映射字段浅拷贝，协作对象在实例之间共享
+	assert.Same(t, shared, first.Shared)
+	assert.Same(t, shared, second.Shared)
+	first.Shared.mutex.Lock()
+	assert.False(t, second.Shared.mutex.TryLock())
+	first.Shared.mutex.Unlock()
+	first.Name = "changed"
+	first.Limits["rows"] = 20
+	assert.Equal(t, "report", second.Name)
+	assert.Equal(t, 20, second.Limits["rows"])
+
+	// 实现了 Clone 的类型由 Clone 创建副本
+	c.RegisterPrototype("cloned", &ClonedPrototype{Tags: []string{"a"}})
+	cloned := c.GetBean("cloned").(*ClonedPrototype)
+	cloned.Tags[0] = "changed"
+	assert.Equal(t, []string{"a"}, c.GetBean("cloned").(*ClonedPrototype).Tags)
+}
+
+func TestContainer_PrototypeProvider(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+
+	c.RegisterSingleton("testRepository", &TestRepositoryImpl{})
+	created := 0
+	assert.NoError(t, c.RegisterPrototypeProvider("job", func(repo TestRepository) *ProviderJob {
+		created++
+		return &ProviderJob{ID: created}
+	}))
+
+	first, err := container.ResolveNamed[*ProviderJob](c, "job")
+	assert.NoError(t, err)
+	second, err := container.ResolveNamed[*ProviderJob](c, "job")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, first.ID)
+	assert.Equal(t, 2, second.ID)
+	assert.False(t, c.GetBeanDefinition("job").Singleton)
+
+	// 单例不能通过 DestroyBean 销毁
+	assert.Error(t, c.DestroyBean("testRepository", c.GetBean("testRepository")))
+	assert.NoError(t, c.DestroyBean("job", first))
+}
+
+type TenantSettings struct {
+	Tenant     string
+	Repository TestRepository `inject:""`
+	_          string         `component:"tenantSettings" scope:"tenant"`
+}
+
+func TestContainer_CustomScope(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+	c.RegisterSingleton("testRepository", &TestRepositoryImpl{})
+
+	scanner.NewComponentScannerWithLogger(c, logging.NopLogger).ScanComponent(&TenantSettings{Tenant: "default"})
+	assert.Equal(t, "tenant", c.GetBeanDefinition("tenantSettings").Scope)
+	assert.False(t, c.GetBeanDefinition("tenantSettings").Singleton)
+
+	// 作用域注册前无法获取
+	_, err := c.LookupBean("tenantSettings")
+	assert.ErrorIs(t, err, container.ErrBeanCreation)
+
+	scope := container.NewSimpleScope()
+	assert.NoError(t, c.RegisterScope("tenant", scope))
+	assert.Error(t, c.RegisterScope(container.ScopeSingleton, scope))
+
+	// 同一作用域内复用实例，实例保留模板字段并完成注入
+	first := c.GetBean("tenantSettings").(*TenantSettings)
+	assert.Same(t, first, c.GetBean("tenantSettings"))
+	assert.Equal(t, "default", first.Tenant)
+	assert.NotNil(t, first.Repository)
+
+	// 作用域结束后创建新实例
+	scope.Close()
+	second := c.GetBean("tenantSettings").(*TenantSettings)
+	assert.NotSame(t, first, second)
+
+	assert.NoError(t, c.DestroyScopedBean("tenantSettings"))
+	assert.NotSame(t, second, c.GetBean("tenantSettings"))
+}
+
+// 请求作用域测试用的组件
+type RequestUser struct {
+	ID         string
+	Repository TestRepository `inject:""`
+	_          string         `component:"requestUser" scope:"request"`
+}
+
+type RequestAudit struct {
+	User *RequestUser `inject:""`
+	_    string       `component:"requestAudit" scope:"request"`
+}
+
+type RequestHandler struct {
+	Users container.Provider[*RequestUser] `inject:""`
+	_     string                           `component:"requestHandler"`
+}
+
+type EagerRequestConsumer struct {
+	User *RequestUser `inject:""`
+}
+
+func TestContainer_RequestScope(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+	c.RegisterSingleton("testRepository", &TestRepositoryImpl{})
+
+	s := scanner.NewComponentScannerWithLogger(c, logging.NopLogger)
+	assert.NoError(t, s.ScanAndRegister(&RequestUser{}, &RequestAudit{}, &RequestHandler{}))
+	assert.NoError(t, c.WireAll())
+
+	// 没有开启请求作用域时无法获取
+	_, err := c.LookupBean("requestUser")
+	assert.ErrorIs(t, err, container.ErrScopeNotActive)
+
+	ctx, closeScope := container.OpenRequestScope(stdcontext.Background())
+	user, err := c.LookupBeanInContext(ctx, "requestUser")
+	assert.NoError(t, err)
+	assert.NotNil(t, user.(*RequestUser).Repository)
+
+	// 同一请求内复用实例，依赖的请求作用域Bean来自同一请求
+	audit, err := container.ResolveInContext[*RequestAudit](ctx, c)
+	assert.NoError(t, err)
+	assert.Same(t, user, audit.User)
+
+	// 单例通过 Provider 在请求上下文中获取
+	handler := c.GetBean("requestHandler").(*RequestHandler)
+	assert.Same(t, user, handler.Users.GetContext(ctx))
+	_, err = handler.Users.Lookup()
+	assert.ErrorIs(t, err, container.ErrScopeNotActive)
+
+	// 不同请求使用不同实例
+	other, closeOther := container.OpenRequestScope(stdcontext.Background())
+	defer closeOther()
+	assert.NotSame(t, user, handler.Users.GetContext(other))
+
+	// 作用域关闭后重新创建
+	closeScope()
+	fresh, err := c.LookupBeanInContext(ctx, "requestUser")
+	assert.NoError(t, err)
+	assert.NotSame(t, user, fresh)
+
+	// 单例直接注入请求作用域Bean时报错
+	err = c.InjectDependencies(&EagerRequestConsumer{})
+	assert.ErrorIs(t, err, container.ErrScopeNotActive)
+}
+
+func TestContainer_ParentContainer(t *testing.T) {
+	parent := container.NewContainerWithLogger(logging.NopLogger)
+	parent.RegisterSingleton("testRepository", &TestRepositoryImpl{})
+	parent.RegisterSingleton("testService", &TestServiceImpl{name: "parent"})
+	parent.RegisterSingleton("formatValidator", &FormatValidator{})
+
+	child := container.NewChildContainer(parent)
+	child.RegisterSingleton("testService", &TestServiceImpl{name: "child"})
+	child.RegisterSingleton("lengthValidator", &LengthValidator{})
+	child.RegisterSingleton("testController", &TestController{})
+	assert.NoError(t, child.WireAll())
+
+	// 本地Bean覆盖父容器中的同名Bean，其余依赖从父容器获取
+	controller := child.GetBean("testController").(*TestController)
+	assert.Equal(t, "child", controller.Service.GetName())
+	assert.Same(t, parent.GetBean("testRepository"), controller.Repository)
+	assert.Equal(t, "parent", parent.GetBean("testService").(TestService).GetName())
+
+	// 按类型查找在本地没有候选时使用父容器
+	repo, err := child.LookupBeanByType(reflect.TypeOf((*TestRepository)(nil)).Elem())
+	assert.NoError(t, err)
+	assert.Same(t, parent.GetBean("testRepository"), repo)
+
+	// 父容器看不到子容器中的Bean
+	assert.False(t, parent.HasBean("testController"))
+	assert.True(t, child.HasBean("testRepository"))
+	assert.False(t, child.HasLocalBean("testRepository"))
+
+	// 本地与包含父容器的列表
+	assert.Equal(t, []string{"testService", "lengthValidator", "testController"}, child.ListBeans())
+	assert.Equal(t, []string{"testService", "lengthValidator", "testController", "testRepository", "formatValidator"},
+		child.ListBeansIncludingAncestors())
+
+	validatorType := reflect.TypeOf((*Validator)(nil)).Elem()
+	assert.Len(t, child.GetBeansOfType(validatorType), 1)
+	assert.Len(t, child.GetBeansOfTypeIncludingAncestors(validatorType), 2)
+
+	// 集合注入包括父容器中的Bean
+	chain := &ValidatorChain{}
+	assert.NoError(t, child.InjectDependencies(chain))
+	assert.Len(t, chain.Validators, 2)
+	assert.Contains(t, chain.ByName, "formatValidator")
+}
+
+type AliasConsumer struct {
+	Repository TestRepository `inject:"repo"`
+}
+
+func TestContainer_Alias(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+	repo := &TestRepositoryImpl{}
+	c.RegisterSingleton("testRepository", repo)
+
+	assert.NoError(t, c.RegisterAlias("repo", "testRepository"))
+	assert.NoError(t, c.RegisterAlias("storage", "repo"))
+	assert.Same(t, repo, c.GetBean("storage"))
+	assert.True(t, c.HasBean("repo"))
+	assert.Equal(t, "testRepository", c.GetBeanDefinition("repo").Name)
+	assert.Equal(t, []string{"repo", "storage"}, c.GetAliases("testRepository"))
+
+	// 按别名注入
+	consumer := &AliasConsumer{}
+	assert.NoError(t, c.InjectDependencies(consumer))
+	assert.Same(t, repo, consumer.Repository)
+
+	// 别名不能与Bean名称冲突或形成循环
+	assert.ErrorIs(t, c.RegisterAlias("testRepository", "repo"), container.ErrDuplicateBean)
+	assert.Error(t, c.RegisterAlias("other", "other"))
+	assert.Error(t, c.RegisterAlias("repo", "testService"))
+	assert.ErrorIs(t, c.RegisterSingleton("repo", &TestRepositoryImpl{}), container.ErrDuplicateBean)
+}
+
+func TestContainer_OverridePolicy(t *testing.T) {
+	logger := &TestLogger{}
+	c := container.NewContainerWithLogger(logger)
+	original := &TestServiceImpl{name: "original"}
+	c.RegisterSingleton("testService", original)
+	c.RegisterSingleton("testRepository", &TestRepositoryImpl{})
+
+	// 默认禁止覆盖
+	assert.ErrorIs(t, c.RegisterSingleton("testService", &TestServiceImpl{}), container.ErrDuplicateBean)
+
+	c.SetOverridePolicy(container.OverrideWarn)
+	replacement := &TestServiceImpl{name: "replacement"}
+	assert.NoError(t, c.RegisterSingleton("testService", replacement))
+
+	// 覆盖的定义保留注册顺序并记录被覆盖的定义
+	beanDef := c.GetBeanDefinition("testService")
+	assert.Same(t, replacement, beanDef.Instance)
+	assert.Same(t, original, beanDef.Overrides.Instance)
+	assert.Equal(t, []string{"testService", "testRepository"}, c.ListBeans())
+
+	service, err := c.LookupBeanByType(reflect.TypeOf((*TestService)(nil)).Elem())
+	assert.NoError(t, err)
+	assert.Same(t, replacement, service)
+
+	var overridden *logging.BeanDefinitionOverridden
+	for _, event := range logger.GetEvents() {
+		if e, ok := event.(*logging.BeanDefinitionOverridden); ok {
+			overridden = e
+		}
+	}
+	if assert.NotNil(t, overridden) {
+		assert.Equal(t, "testService", overridden.ComponentID)
+		assert.True(t, overridden.Warning)
+	}
+
+	// 允许覆盖时以Bean名称取代同名别名
+	c.SetOverridePolicy(container.OverrideAllowed)
+	assert.NoError(t, c.RegisterAlias("service", "testService"))
+	assert.NoError(t, c.RegisterProvider("service", func() *TestServiceImpl { return &TestServiceImpl{name: "provided"} }))
+	assert.Equal(t, "provided", c.GetBean("service").(*TestServiceImpl).GetName())
+	assert.Empty(t, c.GetAliases("testService"))
+}
+
+func TestContainer_OrderedBeanNames(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+	c.RegisterSingleton("format", &FormatValidator{})
+	c.RegisterSingleton("notEmpty", &NotEmptyValidator{})
+	c.RegisterSingleton("length", &LengthValidator{}, container.Order(1))
+
+	// 按排序值排列，Ordered 接口的返回值优先，排序值相同时保持注册顺序
+	validatorType := reflect.TypeOf((*Validator)(nil)).Elem()
+	assert.Equal(t, []string{"length", "notEmpty", "format"}, c.GetBeanNamesForType(validatorType))
+
+	validators, err := container.ResolveAll[Validator](c)
+	assert.NoError(t, err)
+	assert.Equal(t, "length", validators[0].Validate(""))
+
+	order, err := c.InitializationOrder()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"length", "notEmpty", "format"}, order)
+
+	// 无效的 order 或 destroyTimeout 标签在注册时报错，而不是静默使用默认值
+	assert.Error(t, c.RegisterSingleton("badOrder", &BadOrderBean{}))
+	assert.Error(t, c.RegisterProvider("badTimeout", func() *BadDestroyTimeoutBean { return &BadDestroyTimeoutBean{} }))
+	assert.False(t, c.HasLocalBean("badOrder"))
+	assert.False(t, c.HasLocalBean("badTimeout"))
+}
+
+type BadOrderBean struct {
+	_ string `order:"abc"`
+}
+
+type BadDestroyTimeoutBean struct {
+	_ string `destroyTimeout:"10"`
+}
+
+type ServerSettings struct {
+	Port      int               `value:"${server.port:8080}"`
+	Host      string            `value:"${server.host:localhost}"`
+	URL       string            `value:"http://${server.host:localhost}:${server.port:8080}${server.path:}"`
+	Timeout   time.Duration     `value:"${server.timeout:30s}"`
+	Ratio     float64           `value:"${server.ratio:0.75}"`
+	Debug     bool              `value:"${server.debug:false}"`
+	Origins   []string          `value:"${server.origins:}"`
+	Weights   map[string]int    `value:"${server.weights:a=1}"`
+	Bind      net.IP            `value:"${server.bind:127.0.0.1}"`
+	Name      string            `value:"${app.name:${server.host:localhost}-app}"`
+	Fallbacks map[string]string `value:"${server.fallbacks:}"`
+}
+
+func TestContainer_ValueInjection(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+	env := environment.NewStandardEnvironment()
+	env.SetProperty("server.host", "example.com")
+	env.SetProperty("server.timeout", "1m")
+	env.SetProperty("server.origins", "a.com, b.com")
+	env.SetProperty("server.weights", "x=1, y=2")
+	env.SetProperty("server.bind", "10.0.0.1")
+	c.SetEnvironment(env)
+
+	settings := &ServerSettings{}
+	c.RegisterSingleton("settings", settings)
+	assert.NoError(t, c.WireAll())
+
+	// 属性不存在时使用默认值，默认值和整个表达式中都可以嵌套占位符
+	assert.Equal(t, 8080, settings.Port)
+	assert.Equal(t, "example.com", settings.Host)
+	assert.Equal(t, "http://example.com:8080", settings.URL)
+	assert.Equal(t, time.Minute, settings.Timeout)
+	assert.Equal(t, 0.75, settings.Ratio)
+	assert.False(t, settings.Debug)
+	assert.Equal(t, []string{"a.com", "b.com"}, settings.Origins)
+	assert.Equal(t, map[string]int{"x": 1, "y": 2}, settings.Weights)
+	assert.Equal(t, "10.0.0.1", settings.Bind.String())
+	assert.Equal(t, "example.com-app", settings.Name)
+	assert.Empty(t, settings.Fallbacks)
+}
+
+type PortHolder struct {
+	Port int    `value:"${server.port}"`
+	Mode string `value:"${server.mode}"`
+}
+
+func TestContainer_ValueInjectionErrors(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+	c.SetStrictWiring(false)
+	c.GetEnvironment().(*environment.StandardEnvironment).SetProperty("server.port", "abc")
+	c.RegisterSingleton("portHolder", &PortHolder{})
+
+	// 非严格模式下属性值注入失败同样导致装配失败，错误中包含Bean、字段和属性名
+	err := c.WireAll()
+	assert.ErrorIs(t, err, container.ErrValueInjection)
+	assert.ErrorIs(t, err, environment.ErrPropertyConversion)
+	assert.ErrorIs(t, err, environment.ErrPropertyNotFound)
+
+	var valueErr *container.ValueInjectionError
+	assert.True(t, errors.As(err, &valueErr))
+	assert.Equal(t, "portHolder", valueErr.BeanName)
+	assert.Equal(t, "Port", valueErr.FieldName)
+	assert.Equal(t, "server.port", valueErr.Key)
+	assert.Contains(t, err.Error(), "field Mode of bean 'portHolder'")
+	assert.Contains(t, err.Error(), "'server.mode'")
+}
@@ -1,9 +1,24 @@
 package tests
 
 import (
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+	"gospring/container"
 	"gospring/context"
+	"gospring/environment"
+	"gospring/lifecycle"
+	"gospring/logging"
+	"gospring/web"
 )
 
 // 测试用的组件
@@ -231,8 +246,9 @@ func TestApplicationContext_AutoWire(t *testing.T) {
 func TestApplicationContext_Refresh(t *testing.T) {
 	ctx := context.NewApplicationContext()
 	
+	userRepo := &TestUserRepository{}
 	userService := &TestUserService{}
-	ctx.RegisterComponent(userService)
+	ctx.RegisterComponents(userRepo, userService)
 	
 	// 启动
 	err := ctx.Start()
@@ -253,4 +269,1114 @@ func TestApplicationContext_Refresh(t *testing.T) {
 	if !ctx.IsStarted() {
 		t.Error("刷新后上下文应该处于启动状态")
 	}
-}
\ No newline at end of file
+}
+// 用于验证初始化顺序的组件
+type OrderedCache struct {
+	_ string `component:"orderedCache"`
+}
+
+type OrderedRepository struct {
+	Cache *OrderedCache `inject:"orderedCache"`
+	_     string        `component:"orderedRepository"`
+}
+
+type OrderedService struct {
+	Repository *OrderedRepository `inject:""`
+	Cache      *OrderedCache      `inject:""`
+	_          string             `component:"orderedService"`
+}
+
+type OrderedController struct {
+	Service *OrderedService `inject:"orderedService"`
+	_       string          `component:"orderedController"`
+}
+
+func TestApplicationContext_InitializationOrder(t *testing.T) {
+	ctx := context.NewApplicationContext()
+
+	// 以与依赖关系相反的顺序注册
+	err := ctx.RegisterComponents(
+		&OrderedController{},
+		&OrderedService{},
+		&OrderedRepository{},
+		&OrderedCache{},
+	)
+	if err != nil {
+		t.Fatalf("注册组件失败: %v", err)
+	}
+
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("启动上下文失败: %v", err)
+	}
+
+	expected := []string{"orderedCache", "orderedRepository", "orderedService", "orderedController"}
+	if !reflect.DeepEqual(ctx.GetLifecycleManager().GetInitOrder(), expected) {
+		t.Errorf("期望初始化顺序 %v, 得到 %v", expected, ctx.GetLifecycleManager().GetInitOrder())
+	}
+
+	if err := ctx.Stop(); err != nil {
+		t.Fatalf("停止上下文失败: %v", err)
+	}
+
+	// 销毁按初始化的逆序进行，GetDestroyOrder 以逆序记录，因此与初始化顺序一致
+	if !reflect.DeepEqual(ctx.GetLifecycleManager().GetDestroyOrder(), expected) {
+		t.Errorf("期望销毁记录 %v, 得到 %v", expected, ctx.GetLifecycleManager().GetDestroyOrder())
+	}
+}
+
+// 用于严格装配测试的组件
+type StrictMissingDependency struct {
+	Repository *TestUserRepository `inject:"missingRepository"`
+	Cache      *OrderedCache       `inject:""`
+	_          string              `component:"strictMissing"`
+}
+
+type StrictMismatchedDependency struct {
+	Repository *TestUserRepository `inject:"strictMissing"`
+	_          string              `component:"strictMismatched"`
+}
+
+type StrictOptionalDependency struct {
+	Repository *TestUserRepository `inject:"missingRepository,optional"`
+	Cache      *OrderedCache       `inject:",optional"`
+	_          string              `component:"strictOptional"`
+}
+
+func TestApplicationContext_StrictWiring(t *testing.T) {
+	ctx := context.NewApplicationContext()
+	ctx.RegisterComponents(&StrictMissingDependency{}, &StrictMismatchedDependency{}, &StrictOptionalDependency{})
+
+	err := ctx.Start()
+	if err == nil {
+		t.Fatal("存在无法满足的依赖时启动应该失败")
+	}
+	if ctx.IsStarted() {
+		t.Error("启动失败后上下文不应处于启动状态")
+	}
+
+	var wiringErr *container.WiringError
+	if !errors.As(err, &wiringErr) {
+		t.Fatalf("期望 WiringError, 得到 %T", err)
+	}
+	if len(wiringErr.Errors) != 3 {
+		t.Fatalf("期望3个注入失败, 得到%d个: %v", len(wiringErr.Errors), err)
+	}
+
+	failed := make(map[string]bool)
+	for _, e := range wiringErr.Errors {
+		var unsatisfied *container.UnsatisfiedDependencyError
+		if !errors.As(e, &unsatisfied) {
+			t.Fatalf("期望 UnsatisfiedDependencyError, 得到 %T", e)
+		}
+		failed[unsatisfied.BeanName+"."+unsatisfied.FieldName] = true
+	}
+	for _, expected := range []string{"strictMissing.Repository", "strictMissing.Cache", "strictMismatched.Repository"} {
+		if !failed[expected] {
+			t.Errorf("错误中应该包含 %s: %v", expected, err)
+		}
+	}
+}
+
+func TestApplicationContext_NonStrictWiring(t *testing.T) {
+	ctx := context.NewApplicationContext()
+	ctx.SetStrictWiring(false)
+
+	missing := &StrictMissingDependency{}
+	ctx.RegisterComponents(missing, &StrictOptionalDependency{})
+
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("非严格模式下启动不应失败: %v", err)
+	}
+	if missing.Repository != nil {
+		t.Error("无法解析的依赖应该保持为nil")
+	}
+}
+
+func TestApplicationContext_OptionalInjection(t *testing.T) {
+	ctx := context.NewApplicationContext()
+
+	optional := &StrictOptionalDependency{}
+	ctx.RegisterComponent(optional)
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("可选依赖缺失时启动不应失败: %v", err)
+	}
+	if optional.Repository != nil || optional.Cache != nil {
+		t.Error("缺失的可选依赖应该保持为nil")
+	}
+}
+
+// 用于延迟初始化测试的组件
+type LazyReportService struct {
+	Cache     *OrderedCache `inject:""`
+	initCount int
+	_         string `component:"lazyReportService" lazy:"true"`
+}
+
+func (s *LazyReportService) Init() error {
+	s.initCount++
+	return nil
+}
+
+type LazyReportConsumer struct {
+	Reports container.Lazy[*LazyReportService] `inject:""`
+	_       string                             `component:"lazyReportConsumer"`
+}
+
+func TestApplicationContext_LazyBean(t *testing.T) {
+	ctx := context.NewApplicationContext()
+
+	report := &LazyReportService{}
+	consumer := &LazyReportConsumer{}
+	if err := ctx.RegisterComponents(report, consumer, &OrderedCache{}); err != nil {
+		t.Fatalf("注册组件失败: %v", err)
+	}
+
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("启动上下文失败: %v", err)
+	}
+
+	// 启动时既不注入也不初始化延迟Bean
+	if report.Cache != nil || report.initCount != 0 {
+		t.Error("延迟Bean不应在启动时注入或初始化")
+	}
+	expected := []string{"orderedCache", "lazyReportConsumer"}
+	if !reflect.DeepEqual(ctx.GetLifecycleManager().GetInitOrder(), expected) {
+		t.Errorf("期望初始化顺序 %v, 得到 %v", expected, ctx.GetLifecycleManager().GetInitOrder())
+	}
+
+	// 首次获取时注入依赖并初始化，之后不再重复
+	if consumer.Reports.Get() != report || consumer.Reports.Get() != report {
+		t.Fatal("Lazy 应该解析到延迟Bean")
+	}
+	ctx.GetBean("lazyReportService")
+	if report.Cache == nil || report.initCount != 1 {
+		t.Errorf("延迟Bean应该在首次获取时注入并初始化一次，初始化次数 %d", report.initCount)
+	}
+
+	if err := ctx.Stop(); err != nil {
+		t.Fatalf("停止上下文失败: %v", err)
+	}
+
+	// 延迟初始化的Bean同样参与销毁，且最先销毁（GetDestroyOrder 以逆序记录）
+	destroyOrder := ctx.GetLifecycleManager().GetDestroyOrder()
+	if len(destroyOrder) != 3 || destroyOrder[2] != "lazyReportService" {
+		t.Errorf("延迟Bean应该参与销毁，得到 %v", destroyOrder)
+	}
+}
+
+type LazyAuditService struct {
+	initCount    int
+	destroyCount int
+	_            string `component:"lazyAuditService" lazy:"true"`
+}
+
+func (s *LazyAuditService) Init() error {
+	s.initCount++
+	return nil
+}
+
+func (s *LazyAuditService) Destroy() error {
+	s.destroyCount++
+	return nil
+}
+
+func TestApplicationContext_LazyBeanFetchedBeforeStart(t *testing.T) {
+	ctx := context.NewApplicationContext()
+
+	audit := &LazyAuditService{}
+	if err := ctx.RegisterComponents(audit); err != nil {
+		t.Fatalf("注册组件失败: %v", err)
+	}
+
+	// 启动前获取延迟Bean会立即初始化
+	if ctx.GetBean("lazyAuditService") != audit {
+		t.Fatal("应该获取到延迟Bean")
+	}
+
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("启动上下文失败: %v", err)
+	}
+	if err := ctx.Stop(); err != nil {
+		t.Fatalf("停止上下文失败: %v", err)
+	}
+
+	// 启动时不重复初始化，停止时仍然销毁
+	if audit.initCount != 1 || audit.destroyCount != 1 {
+		t.Errorf("期望初始化1次、销毁1次，得到 inits=%d destroys=%d", audit.initCount, audit.destroyCount)
+	}
+}
+
+type EagerReportConsumer struct {
+	Reports *LazyReportService `inject:""`
+	_       string             `component:"eagerReportConsumer"`
+}
+
+func TestApplicationContext_LazyBeanInjectedEagerly(t *testing.T) {
+	ctx := context.NewApplicationContext()
+
+	report := &LazyReportService{}
+	if err := ctx.RegisterComponents(&EagerReportConsumer{}, report, &OrderedCache{}); err != nil {
+		t.Fatalf("注册组件失败: %v", err)
+	}
+
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("启动上下文失败: %v", err)
+	}
+
+	// 被非延迟Bean直接注入时在启动时创建，并按依赖顺序初始化
+	expected := []string{"orderedCache", "lazyReportService", "eagerReportConsumer"}
+	if !reflect.DeepEqual(ctx.GetLifecycleManager().GetInitOrder(), expected) {
+		t.Errorf("期望初始化顺序 %v, 得到 %v", expected, ctx.GetLifecycleManager().GetInitOrder())
+	}
+	if report.initCount != 1 {
+		t.Errorf("延迟Bean应该只初始化一次，得到 %d", report.initCount)
+	}
+}
+
+// 用于原型生命周期测试的组件
+type PrototypeSession struct {
+	User       string
+	Repository *TestUserRepository `inject:"userRepository"`
+	initCount  int
+	destroyed  bool
+	_          string `component:"session" singleton:"false"`
+}
+
+func (s *PrototypeSession) Init() error {
+	s.initCount++
+	return nil
+}
+
+func (s *PrototypeSession) Destroy() error {
+	s.destroyed = true
+	return nil
+}
+
+func TestApplicationContext_PrototypeLifecycle(t *testing.T) {
+	ctx := context.NewApplicationContext()
+
+	if err := ctx.RegisterComponents(&PrototypeSession{User: "guest"}, &TestUserRepository{}); err != nil {
+		t.Fatalf("注册组件失败: %v", err)
+	}
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("启动上下文失败: %v", err)
+	}
+
+	// 原型不参与启动时的初始化
+	for _, name := range ctx.GetLifecycleManager().GetInitOrder() {
+		if name == "session" {
+			t.Error("原型Bean不应在启动时初始化")
+		}
+	}
+
+	session := ctx.GetBean("session").(*PrototypeSession)
+	other := ctx.GetBean("session").(*PrototypeSession)
+	if session == other {
+		t.Fatal("每次获取原型Bean都应该得到新实例")
+	}
+	if session.User != "guest" || session.Repository == nil {
+		t.Error("原型实例应该保留模板字段并完成注入")
+	}
+	if session.initCount != 1 || other.initCount != 1 {
+		t.Errorf("每个原型实例都应该初始化一次，得到 %d 和 %d", session.initCount, other.initCount)
+	}
+
+	if err := ctx.DestroyBean("session", session); err != nil {
+		t.Fatalf("销毁原型实例失败: %v", err)
+	}
+	if !session.destroyed || other.destroyed {
+		t.Error("DestroyBean 只应销毁指定的原型实例")
+	}
+
+	ctx.Stop()
+}
+
+// 用于自定义作用域测试的组件
+type JobContext struct {
+	destroyed bool
+	_         string `component:"jobContext" scope:"job"`
+}
+
+func (j *JobContext) Destroy() error {
+	j.destroyed = true
+	return nil
+}
+
+func TestApplicationContext_CustomScope(t *testing.T) {
+	ctx := context.NewApplicationContext()
+
+	jobScope := container.NewSimpleScope()
+	if err := ctx.RegisterScope("job", jobScope); err != nil {
+		t.Fatalf("注册作用域失败: %v", err)
+	}
+	if err := ctx.RegisterBean("jobContext", &JobContext{}); err != nil {
+		t.Fatalf("注册Bean失败: %v", err)
+	}
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("启动上下文失败: %v", err)
+	}
+
+	job := ctx.GetBean("jobContext").(*JobContext)
+	if job != ctx.GetBean("jobContext") {
+		t.Error("同一作用域内应该复用实例")
+	}
+
+	// 作用域结束时执行销毁回调
+	jobScope.Close()
+	if !job.destroyed {
+		t.Error("作用域结束时应该销毁实例")
+	}
+
+	ctx.Stop()
+}
+
+type RequestTrace struct {
+	destroyed bool
+	_         string `component:"requestTrace" scope:"request"`
+}
+
+func (r *RequestTrace) Destroy() error {
+	r.destroyed = true
+	return nil
+}
+
+func TestApplicationContext_RequestScopeMiddleware(t *testing.T) {
+	ctx := context.NewApplicationContext()
+	if err := ctx.RegisterBean("requestTrace", &RequestTrace{}); err != nil {
+		t.Fatalf("注册Bean失败: %v", err)
+	}
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("启动上下文失败: %v", err)
+	}
+	defer ctx.Stop()
+
+	var traces []*RequestTrace
+	handler := web.RequestScope(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		first, err := ctx.LookupBeanInContext(r.Context(), "requestTrace")
+		if err != nil {
+			t.Fatalf("获取请求作用域Bean失败: %v", err)
+		}
+		second, _ := ctx.LookupBeanInContext(r.Context(), "requestTrace")
+		if first != second {
+			t.Error("同一请求内应该复用实例")
+		}
+		traces = append(traces, first.(*RequestTrace))
+	}))
+
+	for i := 0; i < 2; i++ {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	if len(traces) != 2 || traces[0] == traces[1] {
+		t.Fatal("不同请求应该使用不同实例")
+	}
+	for _, trace := range traces {
+		if !trace.destroyed {
+			t.Error("请求结束时应该销毁实例")
+		}
+	}
+}
+
+type SharedDataSource struct {
+	destroyed bool
+}
+
+func (d *SharedDataSource) Destroy() error {
+	d.destroyed = true
+	return nil
+}
+
+type AdminController struct {
+	DataSource *SharedDataSource `inject:""`
+	destroyed  bool
+}
+
+func (a *AdminController) Destroy() error {
+	a.destroyed = true
+	return nil
+}
+
+func TestApplicationContext_ParentContext(t *testing.T) {
+	parent := context.NewApplicationContext()
+	dataSource := &SharedDataSource{}
+	parent.RegisterSingleton("dataSource", dataSource)
+	if err := parent.Start(); err != nil {
+		t.Fatalf("启动父上下文失败: %v", err)
+	}
+
+	admin := context.NewChildApplicationContext(parent)
+	controller := &AdminController{}
+	admin.RegisterSingleton("adminController", controller)
+	if err := admin.Start(); err != nil {
+		t.Fatalf("启动子上下文失败: %v", err)
+	}
+	if admin.GetParent() != parent {
+		t.Error("子上下文应该返回父上下文")
+	}
+
+	if controller.DataSource != dataSource {
+		t.Error("子上下文应该注入父上下文中的Bean")
+	}
+	if parent.HasBean("adminController") {
+		t.Error("父上下文不应该看到子上下文中的Bean")
+	}
+
+	// 停止子上下文只销毁本地Bean
+	admin.Stop()
+	if !controller.destroyed {
+		t.Error("停止子上下文应该销毁本地Bean")
+	}
+	if dataSource.destroyed {
+		t.Error("停止子上下文不应该销毁父上下文中的Bean")
+	}
+
+	parent.Stop()
+	if !dataSource.destroyed {
+		t.Error("停止父上下文应该销毁其中的Bean")
+	}
+}
+
+// 用于 dependsOn 和排序测试的组件，相互之间没有注入关系
+type CacheWarmer struct {
+	_ string `component:"cacheWarmer" dependsOn:"migrations"`
+}
+
+type Migrations struct {
+	_ string `component:"migrations" lazy:"true"`
+}
+
+type MetricsReporter struct {
+	_ string `component:"metricsReporter" order:"1"`
+}
+
+func TestApplicationContext_DependsOnAndOrder(t *testing.T) {
+	ctx := context.NewApplicationContext()
+	err := ctx.RegisterComponents(&CacheWarmer{}, &Migrations{}, &MetricsReporter{})
+	if err != nil {
+		t.Fatalf("注册组件失败: %v", err)
+	}
+	ctx.RegisterSingleton("auditLog", &OrderedCache{}, container.DependsOn("cacheWarmer"))
+
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("启动上下文失败: %v", err)
+	}
+
+	// 延迟的 migrations 因被依赖而随之初始化，独立的Bean按排序值在前
+	expected := []string{"metricsReporter", "migrations", "cacheWarmer", "auditLog"}
+	if !reflect.DeepEqual(ctx.GetLifecycleManager().GetInitOrder(), expected) {
+		t.Errorf("期望初始化顺序 %v, 得到 %v", expected, ctx.GetLifecycleManager().GetInitOrder())
+	}
+
+	if err := ctx.Stop(); err != nil {
+		t.Fatalf("停止上下文失败: %v", err)
+	}
+}
+
+func TestApplicationContext_DependsOnMissing(t *testing.T) {
+	ctx := context.NewApplicationContext()
+	ctx.RegisterComponent(&CacheWarmer{})
+
+	err := ctx.Start()
+	if !errors.Is(err, container.ErrUnsatisfiedDependency) {
+		t.Fatalf("期望 ErrUnsatisfiedDependency, 得到 %v", err)
+	}
+	if !errors.Is(err, container.ErrBeanNotFound) {
+		t.Errorf("期望原因为 ErrBeanNotFound, 得到 %v", err)
+	}
+}
+
+// 用于条件注册测试的组件
+type MailSender interface {
+	Send(to string) string
+}
+
+type SMTPMailSender struct{}
+
+func (s *SMTPMailSender) Send(to string) string { return "smtp:" + to }
+
+type NoopMailSender struct{}
+
+func (s *NoopMailSender) Send(to string) string { return "noop:" + to }
+
+type MailNotifier struct {
+	Sender MailSender `inject:""`
+}
+
+func TestApplicationContext_ConditionalBeans(t *testing.T) {
+	logger := &TestLogger{}
+	ctx := context.NewApplicationContextWithLogger(logger)
+	ctx.GetEnvironment().SetProperty("metrics.enabled", "true")
+	ctx.GetEnvironment().SetActiveProfiles("dev")
+
+	mailSenderType := reflect.TypeOf((*MailSender)(nil)).Elem()
+
+	// 库提供的默认实现在应用注册自己的实现时被跳过
+	ctx.RegisterSingleton("defaultMailSender", &NoopMailSender{}, container.ConditionalOnMissingBean(mailSenderType))
+	ctx.RegisterSingleton("mailSender", &SMTPMailSender{})
+	ctx.RegisterSingleton("mailNotifier", &MailNotifier{}, container.ConditionalOnBean(mailSenderType))
+
+	ctx.RegisterSingleton("metrics", &SharedDataSource{}, container.ConditionalOnProperty("metrics.enabled", "true"))
+	ctx.RegisterSingleton("tracing", &SharedDataSource{}, container.ConditionalOnProperty("tracing.enabled", ""))
+	ctx.RegisterSingleton("devTools", &SharedDataSource{}, container.ConditionalOnProfile("dev"))
+	ctx.RegisterSingleton("prodTools", &SharedDataSource{}, container.ConditionalOnProfile("prod"))
+	ctx.RegisterSingleton("custom", &SharedDataSource{}, container.ConditionalOn("always false", func(env environment.Environment) bool {
+		return false
+	}))
+
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("启动上下文失败: %v", err)
+	}
+	defer ctx.Stop()
+
+	expected := []string{"mailSender", "mailNotifier", "metrics", "devTools"}
+	if !reflect.DeepEqual(ctx.ListBeans(), expected) {
+		t.Errorf("期望保留的Bean %v, 得到 %v", expected, ctx.ListBeans())
+	}
+	if got := ctx.GetBean("mailNotifier").(*MailNotifier).Sender.Send("a"); got != "smtp:a" {
+		t.Errorf("期望注入应用注册的实现, 得到 %s", got)
+	}
+
+	// 每个被跳过的Bean记录一个说明原因的事件
+	skipped := make(map[string]string)
+	for _, event := range logger.GetEvents() {
+		if e, ok := event.(*logging.BeanSkipped); ok {
+			skipped[e.ComponentID] = e.Condition
+		}
+	}
+	if len(skipped) != 4 {
+		t.Errorf("期望4个跳过事件, 得到 %v", skipped)
+	}
+	if skipped["tracing"] != "property 'tracing.enabled' is set" {
+		t.Errorf("跳过原因不正确: %s", skipped["tracing"])
+	}
+}
+
+type ProfileReporter struct {
+	profiles []string
+	_        string `component:"profileReporter"`
+}
+
+func (p *ProfileReporter) SetEnvironment(env environment.Environment) {
+	p.profiles = env.GetActiveProfiles()
+}
+
+func TestApplicationContext_Profiles(t *testing.T) {
+	ctx := context.NewApplicationContext()
+	ctx.SetActiveProfiles("dev")
+
+	ctx.RegisterSingleton("devMailSender", &NoopMailSender{}, container.Profile("dev"))
+	ctx.RegisterSingleton("prodMailSender", &SMTPMailSender{}, container.Profile("prod"))
+	if err := ctx.RegisterComponent(&ProfileReporter{}); err != nil {
+		t.Fatalf("注册组件失败: %v", err)
+	}
+
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("启动上下文失败: %v", err)
+	}
+	defer ctx.Stop()
+
+	if ctx.HasBean("prodMailSender") || !ctx.HasBean("devMailSender") {
+		t.Errorf("只应注册 dev 配置文件下的Bean, 得到 %v", ctx.ListBeans())
+	}
+
+	// Bean 通过 EnvironmentAware 获取激活的配置文件
+	reporter := ctx.GetBean("profileReporter").(*ProfileReporter)
+	if !reflect.DeepEqual(reporter.profiles, []string{"dev"}) {
+		t.Errorf("期望激活的配置文件 [dev], 得到 %v", reporter.profiles)
+	}
+}
+
+type EnvironmentConsumer struct {
+	Env      environment.Environment          `inject:""`
+	Standard *environment.StandardEnvironment `inject:""`
+}
+
+func TestApplicationContext_InjectEnvironment(t *testing.T) {
+	ctx := context.NewApplicationContext()
+	ctx.GetEnvironment().SetProperty("app.name", "demo")
+
+	consumer := &EnvironmentConsumer{}
+	ctx.RegisterSingleton("environmentConsumer", consumer)
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("启动上下文失败: %v", err)
+	}
+	defer ctx.Stop()
+
+	if consumer.Standard != ctx.GetEnvironment() {
+		t.Error("应该注入上下文的运行环境")
+	}
+	if name, _ := consumer.Env.GetProperty("app.name"); name != "demo" {
+		t.Errorf("期望属性 demo, 得到 %s", name)
+	}
+	if ctx.HasBean("environment") {
+		t.Error("运行环境不应作为Bean出现")
+	}
+}
+
+type ServerConfig struct {
+	_        string `config:"server"`
+	Port     int    `default:"8080" validate:"min=1,max=65535"`
+	Host     string `validate:"required"`
+	Timeouts struct {
+		Read time.Duration `default:"5s"`
+	}
+}
+
+type HTTPServer struct {
+	Config *ServerConfig `inject:""`
+}
+
+func TestApplicationContext_ConfigProperties(t *testing.T) {
+	ctx := context.NewApplicationContext()
+	ctx.GetEnvironment().SetProperty("server.host", "example.com")
+	ctx.GetEnvironment().SetProperty("server.timeouts.read", "2s")
+
+	server := &HTTPServer{}
+	if err := ctx.RegisterComponents(&ServerConfig{}); err != nil {
+		t.Fatalf("注册配置对象失败: %v", err)
+	}
+	ctx.RegisterSingleton("httpServer", server)
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("启动上下文失败: %v", err)
+	}
+	defer ctx.Stop()
+
+	if !ctx.HasBean("serverconfig") {
+		t.Fatal("配置对象应该以类型名称注册")
+	}
+	config := server.Config
+	if config == nil || config.Host != "example.com" || config.Port != 8080 || config.Timeouts.Read != 2*time.Second {
+		t.Errorf("配置属性绑定错误: %+v", config)
+	}
+}
+
+func TestApplicationContext_ConfigPropertiesInvalid(t *testing.T) {
+	ctx := context.NewApplicationContext()
+	ctx.GetEnvironment().SetProperty("server.port", "70000")
+	ctx.GetEnvironment().SetProperty("server.timeouts.read", "soon")
+	ctx.RegisterSingleton("serverConfig", &ServerConfig{})
+
+	// 启动失败，错误中列出所有无效属性
+	err := ctx.Start()
+	if !errors.Is(err, environment.ErrPropertyBinding) {
+		t.Fatalf("期望配置属性绑定错误, 得到 %v", err)
+	}
+	var bindingErr *environment.BindingError
+	if !errors.As(err, &bindingErr) || len(bindingErr.Errors) != 3 {
+		t.Fatalf("期望3个无效属性, 得到 %v", err)
+	}
+	for _, key := range []string{"server.port", "server.host", "server.timeouts.read"} {
+		if !strings.Contains(err.Error(), "'"+key+"'") {
+			t.Errorf("错误信息应该包含属性 %s: %v", key, err)
+		}
+	}
+}
+
+type GreetingService struct {
+	_        string `component:"greetingService" refreshScope:"true"`
+	Greeting string `value:"${app.greeting:hello}"`
+}
+
+type GreetingClient struct {
+	Config   *ServerConfig                       `inject:""`
+	Latest   container.Provider[*ServerConfig]   `inject:""`
+	Greeting container.Provider[*GreetingService] `inject:""`
+
+	mutex  sync.Mutex
+	events []*environment.ConfigChanged
+}
+
+func (c *GreetingClient) OnConfigChanged(event *environment.ConfigChanged) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.events = append(c.events, event)
+}
+
+func (c *GreetingClient) eventCount() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.events)
+}
+
+func TestApplicationContext_RefreshConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "application.yaml")
+	writeConfig := func(content string, modTime time.Time) {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		os.Chtimes(path, modTime, modTime)
+	}
+	now := time.Now()
+	writeConfig("server:\n  host: a.example.com\n  port: 8080\napp:\n  greeting: hello\n", now.Add(-time.Minute))
+
+	ctx := context.NewApplicationContextWithLogger(logging.NopLogger)
+	if err := ctx.GetEnvironment().LoadConfigFiles(path); err != nil {
+		t.Fatalf("加载配置文件失败: %v", err)
+	}
+	client := &GreetingClient{}
+	ctx.RegisterComponents(&ServerConfig{}, &GreetingService{})
+	ctx.RegisterSingleton("greetingClient", client)
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("启动上下文失败: %v", err)
+	}
+	defer ctx.Stop()
+
+	first := client.Greeting.Get()
+	if first.Greeting != "hello" || client.Greeting.Get() != first {
+		t.Fatal("刷新作用域Bean在刷新前应该复用同一实例")
+	}
+
+	// 没有变化时不产生事件
+	if event, err := ctx.RefreshConfig(); event != nil || err != nil {
+		t.Fatalf("期望没有变化, 得到 %v, %v", event, err)
+	}
+
+	writeConfig("server:\n  host: b.example.com\n  port: 8080\napp:\n  greeting: hi\n", now)
+	event, err := ctx.RefreshConfig()
+	if err != nil {
+		t.Fatalf("刷新配置失败: %v", err)
+	}
+	if !reflect.DeepEqual(event.Keys, []string{"app.greeting", "server.host"}) {
+		t.Errorf("期望变化的属性 [app.greeting server.host], 得到 %v", event.Keys)
+	}
+
+	// 配置对象绑定到新的实例，已注入的实例保持不变，刷新作用域Bean重新创建，监听器收到事件
+	if latest := client.Latest.Get(); latest.Host != "b.example.com" || latest.Port != 8080 {
+		t.Errorf("配置对象应该重新绑定: %+v", latest)
+	}
+	if client.Config.Host != "a.example.com" {
+		t.Errorf("已注入的配置对象不应该被修改: %+v", client.Config)
+	}
+	if second := client.Greeting.Get(); second == first || second.Greeting != "hi" {
+		t.Error("刷新作用域Bean应该在配置变化后重新创建")
+	}
+	if client.eventCount() != 1 {
+		t.Errorf("监听器应该收到1个事件, 得到 %d", client.eventCount())
+	}
+
+	// 绑定失败时配置对象保持原值，不通知监听器
+	writeConfig("server:\n  host: c.example.com\n  port: invalid\n", now.Add(time.Minute))
+	if _, err := ctx.RefreshConfig(); !errors.Is(err, environment.ErrPropertyBinding) {
+		t.Fatalf("期望配置属性绑定错误, 得到 %v", err)
+	}
+	if client.Latest.Get().Host != "b.example.com" || client.eventCount() != 1 {
+		t.Error("绑定失败时配置对象应该保持原值")
+	}
+
+	// 后台轮询检测文件修改
+	ctx.WatchConfig(10 * time.Millisecond)
+	writeConfig("server:\n  host: d.example.com\n", now.Add(2*time.Minute))
+	deadline := time.Now().Add(2 * time.Second)
+	for client.eventCount() < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if client.eventCount() != 2 {
+		t.Fatal("轮询应该检测到配置文件的修改")
+	}
+}
+
+func TestApplicationContext_WatchConfigConcurrentReads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "application.yaml")
+	writeConfig := func(port int, modTime time.Time) {
+		content := fmt.Sprintf("server:\n  host: a.example.com\n  port: %d\n", port)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		os.Chtimes(path, modTime, modTime)
+	}
+	now := time.Now()
+	writeConfig(8000, now.Add(-time.Hour))
+
+	ctx := context.NewApplicationContextWithLogger(logging.NopLogger)
+	if err := ctx.GetEnvironment().LoadConfigFiles(path); err != nil {
+		t.Fatalf("加载配置文件失败: %v", err)
+	}
+	client := &GreetingClient{}
+	ctx.RegisterComponents(&ServerConfig{}, &GreetingService{})
+	ctx.RegisterSingleton("greetingClient", client)
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("启动上下文失败: %v", err)
+	}
+	defer ctx.Stop()
+
+	// 后台重新绑定的同时持续读取配置对象，-race 下不应该报告数据竞争
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				if client.Config.Port != 8000 || client.Latest.Get().Port < 8000 {
+					t.Error("读取到不一致的配置")
+					return
+				}
+			}
+		}
+	}()
+
+	ctx.WatchConfig(time.Millisecond)
+	for i := 1; i <= 5; i++ {
+		writeConfig(8000+i, now.Add(time.Duration(i)*time.Minute))
+		deadline := time.Now().Add(2 * time.Second)
+		for client.eventCount() < i && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	close(stop)
+	<-done
+
+	if port := client.Latest.Get().Port; port != 8005 {
+		t.Errorf("期望最新的端口 8005, 得到 %d", port)
+	}
+}
+
+type DatabaseClient struct {
+	Password string `value:"${db.password}"`
+	PoolSize int    `value:"${db.pool-size}"`
+}
+
+func TestApplicationContext_PropertyDecryptor(t *testing.T) {
+	decryptor, err := environment.NewAESGCMDecryptor([]byte("0123456789abcdef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	password, _ := decryptor.Encrypt("s3cret")
+	poolSize, _ := decryptor.Encrypt("many")
+
+	logger := &TestLogger{}
+	ctx := context.NewApplicationContextWithLogger(logger)
+	ctx.GetEnvironment().SetProperty("db.password", "ENC("+password+")")
+	ctx.GetEnvironment().SetProperty("db.pool-size", "ENC("+poolSize+")")
+	ctx.RegisterSingleton("propertyDecryptor", decryptor)
+	ctx.RegisterSingleton("databaseClient", &DatabaseClient{})
+
+	// 解密后的值无法转换时，错误和日志中不包含明文
+	err = ctx.Start()
+	if !errors.Is(err, container.ErrValueInjection) {
+		t.Fatalf("期望属性值注入错误, 得到 %v", err)
+	}
+	if strings.Contains(err.Error(), "many") {
+		t.Errorf("错误信息不应该包含解密后的值: %v", err)
+	}
+	for _, event := range logger.GetEvents() {
+		if strings.Contains(event.String(), "many") {
+			t.Errorf("日志事件不应该包含解密后的值: %s", event.String())
+		}
+	}
+
+	ctx = context.NewApplicationContextWithLogger(logging.NopLogger)
+	ctx.GetEnvironment().SetProperty("db.password", "ENC("+password+")")
+	ctx.GetEnvironment().SetProperty("db.pool-size", "5")
+	client := &DatabaseClient{}
+	ctx.RegisterSingleton("propertyDecryptor", decryptor)
+	ctx.RegisterSingleton("databaseClient", client)
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("启动上下文失败: %v", err)
+	}
+	defer ctx.Stop()
+
+	if client.Password != "s3cret" || client.PoolSize != 5 {
+		t.Errorf("属性值应该被解密后注入: %+v", client)
+	}
+}
+
+type StoppedRecorder struct {
+	mutex   sync.Mutex
+	stopped []*logging.ContextStopped
+}
+
+func (r *StoppedRecorder) LogEvent(event logging.Event) {
+	if stopped, ok := event.(*logging.ContextStopped); ok {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		r.stopped = append(r.stopped, stopped)
+	}
+}
+
+func (r *StoppedRecorder) last() *logging.ContextStopped {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if len(r.stopped) == 0 {
+		return nil
+	}
+	return r.stopped[len(r.stopped)-1]
+}
+
+type FlushingWriter struct {
+	_       string `component:"flushingWriter" destroyTimeout:"20ms"`
+	flushed bool
+}
+
+// Destroy 等待上下文结束，模拟需要尽量完成的清理
+func (w *FlushingWriter) Destroy(ctx stdcontext.Context) error {
+	<-ctx.Done()
+	w.flushed = true
+	return ctx.Err()
+}
+
+type StuckWorker struct {
+	release chan struct{}
+}
+
+func (w *StuckWorker) Destroy() error {
+	<-w.release
+	return nil
+}
+
+type BrokenPool struct{}
+
+func (p *BrokenPool) Destroy() error {
+	return errors.New("pool close failed")
+}
+
+type UpstreamClient struct{}
+
+// Destroy 返回回调自己的超时错误，销毁时限并没有到达
+func (c *UpstreamClient) Destroy() error {
+	return fmt.Errorf("drain upstream: %w", stdcontext.DeadlineExceeded)
+}
+
+type CleanCache struct {
+	destroyed bool
+}
+
+func (c *CleanCache) Destroy(ctx stdcontext.Context) error {
+	c.destroyed = true
+	return nil
+}
+
+func TestApplicationContext_ShutdownTimeouts(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	recorder := &StoppedRecorder{}
+	ctx := context.NewApplicationContextWithLogger(recorder)
+	ctx.SetDestroyTimeout(50 * time.Millisecond)
+	cache := &CleanCache{}
+	ctx.RegisterSingleton("cleanCache", cache)
+	ctx.RegisterSingleton("upstreamClient", &UpstreamClient{})
+	ctx.RegisterSingleton("brokenPool", &BrokenPool{})
+	ctx.RegisterSingleton("stuckWorker", &StuckWorker{release: release})
+	ctx.RegisterComponent(&FlushingWriter{})
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("启动上下文失败: %v", err)
+	}
+
+	// 超时或失败的Bean不影响其他Bean的销毁，回调自己返回的超时错误按失败处理
+	start := time.Now()
+	err := ctx.Stop()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("停止上下文不应该等待超时的Bean, 用时 %v", elapsed)
+	}
+	if !errors.Is(err, context.ErrShutdown) || !errors.Is(err, lifecycle.ErrLifecycle) {
+		t.Fatalf("期望停止错误, 得到 %v", err)
+	}
+	var shutdownErr *context.ShutdownError
+	errors.As(err, &shutdownErr)
+	if !reflect.DeepEqual(shutdownErr.Failed, []string{"brokenPool", "upstreamClient"}) {
+		t.Errorf("期望失败的Bean [brokenPool upstreamClient], 得到 %v", shutdownErr.Failed)
+	}
+	if !reflect.DeepEqual(shutdownErr.TimedOut, []string{"flushingWriter", "stuckWorker"}) {
+		t.Errorf("期望超时的Bean [flushingWriter stuckWorker], 得到 %v", shutdownErr.TimedOut)
+	}
+	if !cache.destroyed || ctx.IsStarted() {
+		t.Error("其他Bean应该正常销毁，上下文应该停止")
+	}
+
+	stopped := recorder.last()
+	if stopped == nil || !reflect.DeepEqual(stopped.FailedBeans, shutdownErr.Failed) ||
+		!reflect.DeepEqual(stopped.TimedOutBeans, shutdownErr.TimedOut) {
+		t.Errorf("ContextStopped 事件应该报告失败和超时的Bean: %v", stopped)
+	}
+}
+
+func TestApplicationContext_ShutdownTotalTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	ctx := context.NewApplicationContextWithLogger(logging.NopLogger)
+	ctx.SetShutdownTimeout(30 * time.Millisecond)
+	cache := &CleanCache{}
+	ctx.RegisterSingleton("cleanCache", cache)
+	ctx.RegisterSingleton("stuckWorker", &StuckWorker{release: release})
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("启动上下文失败: %v", err)
+	}
+
+	// 总时限耗尽后剩余的Bean不再销毁
+	var shutdownErr *context.ShutdownError
+	if err := ctx.Stop(); !errors.As(err, &shutdownErr) {
+		t.Fatalf("期望停止错误, 得到 %v", err)
+	}
+	if !reflect.DeepEqual(shutdownErr.TimedOut, []string{"stuckWorker", "cleanCache"}) {
+		t.Errorf("期望超时的Bean [stuckWorker cleanCache], 得到 %v", shutdownErr.TimedOut)
+	}
+	if cache.destroyed {
+		t.Error("总时限耗尽后不应该继续销毁")
+	}
+}
+
+func TestApplicationContext_ShutdownHook(t *testing.T) {
+	ctx := context.NewApplicationContextWithLogger(logging.NopLogger)
+	cache := &CleanCache{}
+	ctx.RegisterSingleton("cleanCache", cache)
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("启动上下文失败: %v", err)
+	}
+
+	done := ctx.RegisterShutdownHook(os.Interrupt)
+	process, _ := os.FindProcess(os.Getpid())
+	if err := process.Signal(os.Interrupt); err != nil {
+		t.Skipf("无法发送信号: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("停止上下文失败: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("收到信号后应该停止上下文")
+	}
+	if ctx.IsStarted() || !cache.destroyed {
+		t.Error("收到信号后上下文应该停止")
+	}
+
+	// 以其他方式停止时钩子被撤销
+	ctx.Start()
+	done = ctx.RegisterShutdownHook(os.Interrupt)
+	ctx.Stop()
+	if _, ok := <-done; ok {
+		t.Error("钩子撤销后通道应该直接关闭")
+	}
+}
+
+func TestApplicationContext_ShutdownHookConcurrentIsStarted(t *testing.T) {
+	ctx := context.NewApplicationContextWithLogger(logging.NopLogger)
+	ctx.RegisterSingleton("cleanCache", &CleanCache{})
+	if err := ctx.Start(); err != nil {
+		t.Fatalf("启动上下文失败: %v", err)
+	}
+
+	// 钩子在自己的协程中停止上下文，同时读取状态不应产生数据竞争（配合 -race 运行）
+	done := ctx.RegisterShutdownHook(os.Interrupt)
+	process, _ := os.FindProcess(os.Getpid())
+	if err := process.Signal(os.Interrupt); err != nil {
+		t.Skipf("无法发送信号: %v", err)
+	}
+
+	timeout := time.After(2 * time.Second)
+	for ctx.IsStarted() {
+		select {
+		case <-timeout:
+			t.Fatal("收到信号后应该停止上下文")
+		default:
+		}
+	}
+	if err := <-done; err != nil {
+		t.Errorf("停止上下文失败: %v", err)
+	}
+}
@@ -1,8 +1,10 @@
 package tests
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 	"gospring/lifecycle"
 	"gospring/logging"
 	"github.com/stretchr/testify/assert"
@@ -225,6 +227,122 @@ func TestLifecycleManager_ProcessDestruction_ReflectionMethod(t *testing.T) {
 	assert.Contains(t, lm.GetDestroyOrder(), "reflectionService")
 }
 
+type TestCountingDestroyService struct {
+	preDestroyCount int
+	destroyCount    int
+	closed          bool
+}
+
+func (s *TestCountingDestroyService) PreDestroy() error {
+	s.preDestroyCount++
+	return nil
+}
+
+func (s *TestCountingDestroyService) Destroy() error {
+	s.destroyCount++
+	return nil
+}
+
+func (s *TestCountingDestroyService) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestLifecycleManager_ProcessDestruction_InterfaceCalledOnce(t *testing.T) {
+	lm := lifecycle.NewLifecycleManagerWithLogger(logging.NopLogger)
+	service := &TestCountingDestroyService{}
+
+	err := lm.ProcessDestruction("countingService", service)
+
+	// 通过接口调用过的 Destroy 和 PreDestroy 不会再被反射调用，也不会继续查找 Close
+	assert.NoError(t, err)
+	assert.Equal(t, 1, service.preDestroyCount)
+	assert.Equal(t, 1, service.destroyCount)
+	assert.False(t, service.closed)
+}
+
+type TestContextDestroyService struct {
+	_           string `destroy-method:"Flush"`
+	destroyed   bool
+	closed      bool
+	flushed     bool
+	hasDeadline bool
+}
+
+func (s *TestContextDestroyService) Destroy(ctx context.Context) error {
+	_, s.hasDeadline = ctx.Deadline()
+	s.destroyed = true
+	return nil
+}
+
+func (s *TestContextDestroyService) Close() error {
+	s.closed = true
+	return nil
+}
+
+func (s *TestContextDestroyService) Flush(ctx context.Context) error {
+	s.flushed = true
+	return ctx.Err()
+}
+
+type TestContextCloseService struct {
+	closed      bool
+	hasDeadline bool
+}
+
+func (s *TestContextCloseService) Close(ctx context.Context) error {
+	_, s.hasDeadline = ctx.Deadline()
+	s.closed = true
+	return nil
+}
+
+type TestBlockingDestroyService struct {
+	release chan struct{}
+}
+
+func (s *TestBlockingDestroyService) Destroy() error {
+	<-s.release
+	return nil
+}
+
+func TestLifecycleManager_ProcessDestructionContext(t *testing.T) {
+	lm := lifecycle.NewLifecycleManagerWithLogger(logging.NopLogger)
+	service := &TestContextDestroyService{}
+
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := lm.ProcessDestructionContext(c, "contextService", service)
+
+	// 接收上下文的 Destroy 和 destroy-method 方法都获得 ctx，与无参数的 Destroy 一样不再调用 Close
+	assert.NoError(t, err)
+	assert.True(t, service.destroyed)
+	assert.True(t, service.hasDeadline)
+	assert.False(t, service.closed)
+	assert.True(t, service.flushed)
+	assert.Contains(t, lm.GetDestroyOrder(), "contextService")
+
+	// 没有 Destroy 时调用接收上下文的 Close
+	closer := &TestContextCloseService{}
+	assert.NoError(t, lm.ProcessDestructionContext(c, "closeService", closer))
+	assert.True(t, closer.closed)
+	assert.True(t, closer.hasDeadline)
+}
+
+func TestLifecycleManager_ProcessDestructionContext_Timeout(t *testing.T) {
+	lm := lifecycle.NewLifecycleManagerWithLogger(logging.NopLogger)
+	service := &TestBlockingDestroyService{release: make(chan struct{})}
+	defer close(service.release)
+
+	c, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := lm.ProcessDestructionContext(c, "blockingService", service)
+
+	// 回调没有在时限内返回时不再等待
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, err, lifecycle.ErrLifecycle)
+	assert.Contains(t, lm.GetDestroyOrder(), "blockingService")
+}
+
 func TestLifecycleManager_InitDestroyOrder(t *testing.T) {
 	lm := lifecycle.NewLifecycleManagerWithLogger(logging.NopLogger)
 	
@@ -323,4 +441,23 @@ func TestLifecycleManager_ErrorHandling(t *testing.T) {
 	err = lm.ProcessDestruction("errorService", errorService)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "destroy failed")
-}
\ No newline at end of file
+}
+func TestLifecycleManager_LifecycleError(t *testing.T) {
+	lm := lifecycle.NewLifecycleManagerWithLogger(logging.NopLogger)
+	cause := errors.New("init failed")
+
+	err := lm.ProcessInitialization("errorService", &TestLifecycleService{initError: cause})
+
+	var lifecycleErr *lifecycle.LifecycleError
+	assert.True(t, errors.As(err, &lifecycleErr))
+	assert.Equal(t, "errorService", lifecycleErr.BeanName)
+	assert.Equal(t, lifecycle.PhaseInit, lifecycleErr.Phase)
+	assert.Equal(t, lifecycle.MethodInit, lifecycleErr.Method)
+	assert.ErrorIs(t, err, lifecycle.ErrLifecycle)
+	assert.ErrorIs(t, err, cause)
+
+	err = lm.ProcessDestruction("errorService", &TestErrorService{shouldFailDestroy: true})
+	assert.True(t, errors.As(err, &lifecycleErr))
+	assert.Equal(t, lifecycle.PhaseDestroy, lifecycleErr.Phase)
+	assert.Equal(t, lifecycle.MethodDestroy, lifecycleErr.Method)
+}
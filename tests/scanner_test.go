@@ -1,10 +1,12 @@
 package tests
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 	"time"
 	"gospring/container"
+	"gospring/environment"
 	"gospring/logging"
 	"gospring/scanner"
 	"github.com/stretchr/testify/assert"
@@ -159,6 +161,9 @@ func TestScanComponent_Prototype(t *testing.T) {
 	c := container.NewContainer()
 	s := scanner.NewComponentScanner(c)
 	
+	// 严格装配模式下原型创建时需要能够解析其依赖
+	assert.NoError(t, s.ScanComponent(&ScanTestService{Name: "service"}))
+	
 	controller := &ScanTestController{Info: "test"}
 	err := s.ScanComponent(controller)
 	
@@ -363,4 +368,46 @@ func TestEventTiming(t *testing.T) {
 	duration := end.Sub(start)
 	assert.True(t, duration > 0)
 	assert.True(t, duration < time.Second) // 应该很快完成
-}
\ No newline at end of file
+}
+// TestScanComponent_TypedErrors 测试扫描错误类型
+func TestScanComponent_TypedErrors(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+	s := scanner.NewComponentScannerWithLogger(c, logging.NopLogger)
+
+	err := s.ScanAndRegister(&ScanPlainStruct{Name: "test"})
+	var notComponent *scanner.NotAComponentError
+	assert.True(t, errors.As(err, &notComponent))
+	assert.Equal(t, reflect.TypeOf(ScanPlainStruct{}), notComponent.Type)
+	assert.ErrorIs(t, err, scanner.ErrNotAComponent)
+
+	assert.NoError(t, s.ScanComponent(&ScanTestService{Name: "service"}))
+	err = s.ScanAndRegister(&ScanTestService{Name: "service"})
+	assert.ErrorIs(t, err, container.ErrDuplicateBean)
+}
+
+// 不同配置文件下同名的组件
+type DevDataSource struct {
+	_ string `component:"dataSource" profile:"dev"`
+}
+
+type ProdDataSource struct {
+	_ string `component:"dataSource" profile:"prod"`
+}
+
+type LocalOnlyTool struct {
+	_ string `component:"localTool" profile:"!prod"`
+}
+
+func TestComponentScanner_Profiles(t *testing.T) {
+	c := container.NewContainerWithLogger(logging.NopLogger)
+	env := environment.NewStandardEnvironment()
+	env.SetActiveProfiles("prod")
+	c.SetEnvironment(env)
+
+	s := scanner.NewComponentScannerWithLogger(c, logging.NopLogger)
+	assert.NoError(t, s.ScanAndRegister(&DevDataSource{}, &ProdDataSource{}, &LocalOnlyTool{}))
+
+	// 只注册配置文件成立的组件，同名组件不冲突
+	assert.Equal(t, []string{"dataSource"}, c.ListBeans())
+	assert.IsType(t, &ProdDataSource{}, c.GetBean("dataSource"))
+}
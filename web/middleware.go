@@ -0,0 +1,19 @@
+package web
+
+import (
+	"net/http"
+
+	"gospring/container"
+)
+
+// RequestScope 为每个HTTP请求开启请求作用域的中间件
+//
+// 处理函数通过 r.Context() 获取请求作用域Bean，同一请求内共享同一个实例，
+// 请求处理结束后作用域关闭，实例按创建顺序的逆序销毁。
+func RequestScope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, closeScope := container.OpenRequestScope(r.Context())
+		defer closeScope()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}